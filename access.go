@@ -0,0 +1,196 @@
+package golink
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Access mask bits of the key-protected calls, as listed by api/CallList.
+// API.RefreshAccessBits replaces this table with the server's current list.
+var defaultAccessBits = map[string]int64{
+	"char/AccountBalance":         1 << 0,
+	"char/AssetList":              1 << 1,
+	"char/CalendarEventAttendees": 1 << 2,
+	"char/CharacterSheet":         1 << 3,
+	"char/ContactList":            1 << 4,
+	"char/ContactNotifications":   1 << 5,
+	"char/FacWarStats":            1 << 6,
+	"char/IndustryJobs":           1 << 7,
+	"char/KillMails":              1 << 8,
+	"char/MailBodies":             1 << 9,
+	"char/MailingLists":           1 << 10,
+	"char/MailMessages":           1 << 11,
+	"char/MarketOrders":           1 << 12,
+	"char/Medals":                 1 << 13,
+	"char/Notifications":          1 << 14,
+	"char/NotificationTexts":      1 << 15,
+	"char/Research":               1 << 16,
+	"char/SkillInTraining":        1 << 17,
+	"char/SkillQueue":             1 << 18,
+	"char/Standings":              1 << 19,
+	"char/UpcomingCalendarEvents": 1 << 20,
+	"char/WalletJournal":          1 << 21,
+	"char/WalletTransactions":     1 << 22,
+	"char/AccountStatus":          1 << 25,
+	"char/Contracts":              1 << 26,
+	"char/Locations":              1 << 27,
+	"char/Bookmarks":              1 << 28,
+	"corp/AccountBalance":         1 << 0,
+	"corp/AssetList":              1 << 1,
+	"corp/MemberMedals":           1 << 2,
+	"corp/CorporationSheet":       1 << 3,
+	"corp/ContactList":            1 << 4,
+	"corp/ContainerLog":           1 << 5,
+	"corp/FacWarStats":            1 << 6,
+	"corp/IndustryJobs":           1 << 7,
+	"corp/KillMails":              1 << 8,
+	"corp/MemberSecurity":         1 << 9,
+	"corp/MemberSecurityLog":      1 << 10,
+	"corp/MemberTrackingLimited":  1 << 11,
+	"corp/MarketOrders":           1 << 12,
+	"corp/Medals":                 1 << 13,
+	"corp/OutpostList":            1 << 14,
+	"corp/OutpostServiceDetail":   1 << 15,
+	"corp/Shareholders":           1 << 16,
+	"corp/StarbaseDetail":         1 << 17,
+	"corp/Standings":              1 << 18,
+	"corp/StarbaseList":           1 << 19,
+	"corp/WalletJournal":          1 << 20,
+	"corp/WalletTransactions":     1 << 21,
+	"corp/Titles":                 1 << 22,
+	"corp/Contracts":              1 << 23,
+	"corp/Locations":              1 << 24,
+	"corp/MemberTrackingExtended": 1 << 25,
+	"corp/Bookmarks":              1 << 26,
+}
+
+// Calls covered by the access bit of a differently named call.
+var accessAliases = map[string]string{
+	"account/AccountStatus":    "char/AccountStatus",
+	"char/ContractBids":        "char/Contracts",
+	"char/ContractItems":       "char/Contracts",
+	"corp/ContractBids":        "corp/Contracts",
+	"corp/ContractItems":       "corp/Contracts",
+	"char/IndustryJobsHistory": "char/IndustryJobs",
+	"corp/IndustryJobsHistory": "corp/IndustryJobs",
+	"char/PlanetaryColonies":   "char/AssetList",
+	"char/PlanetaryPins":       "char/AssetList",
+	"char/PlanetaryRoutes":     "char/AssetList",
+	"char/PlanetaryLinks":      "char/AssetList",
+}
+
+// Returned by CredentialedAPI calls the key is not allowed to make, without
+// contacting the server. CharacterId is set if the call was for a character
+// the key does not cover.
+type ErrAccessDenied struct {
+	Path, KeyType        string
+	AccessMask, Required int64
+	CharacterId          int64
+}
+
+func (e *ErrAccessDenied) Error() string {
+	if e.CharacterId != 0 {
+		return fmt.Sprintf("Key does not cover character %v for %v.", e.CharacterId, e.Path)
+	}
+	if e.Required == 0 {
+		return fmt.Sprintf("Key of type %v cannot call %v.", e.KeyType, e.Path)
+	}
+	return fmt.Sprintf("Key with access mask %v cannot call %v, which needs bit %v.", e.AccessMask, e.Path, e.Required)
+}
+
+// Replaces the built-in table of access mask bits with the one from
+// api/CallList.
+func (a *API) RefreshAccessBits() error {
+	l, err := a.CallList()
+	if err != nil {
+		return err
+	}
+	bits := make(map[string]int64)
+	for _, c := range l.Calls {
+		bits[c.Type+"/"+c.Name] = c.AccessMask
+	}
+	a.accessBits = bits
+	return nil
+}
+
+func (a *API) accessBit(call string) (int64, bool) {
+	bits := a.accessBits
+	if bits == nil {
+		bits = defaultAccessBits
+	}
+	bit, ok := bits[call]
+	return bit, ok
+}
+
+// Returns the key's information, fetching it on first use.
+func (a *CredentialedAPI) KeyInfo() (aKInfo, error) {
+	if a.keyInfo == nil {
+		k, err := a.AccountKeyInfo()
+		if err != nil {
+			return k, err
+		}
+		a.keyInfo = &k
+	}
+	return *a.keyInfo, nil
+}
+
+// Checks a call against the key's type, access mask and characters before it
+// is made.
+// Only calls through an *API are checked; other fetchers never reach the
+// server.
+func (a *CredentialedAPI) checkAccess(path string, params url.Values) error {
+	api, ok := a.api.(*API)
+	if !ok {
+		return nil
+	}
+	call := accessCallName(path, params)
+	bit, hasBit := api.accessBit(call)
+	id := params.Get("characterID")
+	if !hasBit && id == "" {
+		return nil
+	}
+	k, err := a.KeyInfo()
+	if err != nil {
+		return err
+	}
+	if hasBit {
+		keyType := k.Type
+		if keyType == "account" {
+			keyType = "char"
+		}
+		if !strings.HasPrefix(call, keyType+"/") {
+			return &ErrAccessDenied{Path: path, KeyType: k.Type, AccessMask: k.AccessMask}
+		}
+		if k.AccessMask&bit == 0 {
+			return &ErrAccessDenied{Path: path, KeyType: k.Type, AccessMask: k.AccessMask, Required: bit}
+		}
+	}
+	// Calls without an access bit, such as eve/CharacterInfo, are still limited
+	// to the key's characters.
+	if id != "" {
+		charId, err := strconv.ParseInt(id, 0, 64)
+		if err != nil {
+			return err
+		}
+		if _, ok := k.Characters[charId]; !ok {
+			return &ErrAccessDenied{Path: path, KeyType: k.Type, AccessMask: k.AccessMask, CharacterId: charId}
+		}
+	}
+	return nil
+}
+
+// Returns the api/CallList name of the call made by path and params.
+func accessCallName(path string, params url.Values) string {
+	if path == "corp/MemberTracking" {
+		if params.Get("extended") == "1" {
+			return "corp/MemberTrackingExtended"
+		}
+		return "corp/MemberTrackingLimited"
+	}
+	if call, ok := accessAliases[path]; ok {
+		return call
+	}
+	return path
+}
@@ -0,0 +1,136 @@
+package golink
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// Serves account/APIKeyInfo for a key of the given type and access mask,
+// passing every other call on to next.
+func keyInfoFetcher(keyType string, accessMask int64, next URLFetcher) URLFetcher {
+	keyInfo := URLPathFetcher(map[string]string{"account/APIKeyInfo": `<key accessMask="` + formatId(accessMask) + `" type="` + keyType + `" expires="">
+    <rowset name="characters" key="characterID" columns="characterID,characterName,corporationID,corporationName">
+        <row characterID="898901870" characterName="Desmont McCallock" corporationID="1000009" corporationName="Caldari Provisions" />
+    </rowset>
+</key>`})
+	return func(path string, params url.Values) (*http.Response, error) {
+		if strings.HasSuffix(path, "/account/APIKeyInfo.xml.aspx") {
+			return keyInfo(path, params)
+		}
+		return next(path, params)
+	}
+}
+
+func TestAccessDenied(t *testing.T) {
+	var calls []string
+	fetcher := URLPathFetcher(map[string]string{"char/MailingLists": `<rowset name="mailingLists" key="listID" columns="listID,displayName" />`})
+	a := NewCredentialedAPI(NewAPI("", nil, keyInfoFetcher("Character", 1<<10, func(path string, params url.Values) (*http.Response, error) {
+		calls = append(calls, path)
+		return fetcher(path, params)
+	})), APICredentials{KeyID: "1", VCode: "x"})
+
+	if _, err := a.CharMailingLists(898901870); err != nil {
+		t.Fatal(err)
+	}
+	_, err := a.CharMailMessages(898901870)
+	denied, ok := err.(*ErrAccessDenied)
+	if !ok {
+		t.Fatalf("Expected *ErrAccessDenied, got %v", err)
+	}
+	if denied.Path != "char/MailMessages" || denied.Required != 1<<11 || denied.AccessMask != 1<<10 {
+		t.Errorf("Wrong error: %+v", denied)
+	}
+	if _, err := a.CorpStandings(); err == nil {
+		t.Error("Character key was allowed to make a corporation call.")
+	}
+	if len(calls) != 1 {
+		t.Errorf("Denied calls reached the server: %v", calls)
+	}
+}
+
+func TestAccessMemberTracking(t *testing.T) {
+	a := NewCredentialedAPI(NewAPI("", nil, keyInfoFetcher("Corporation", 1<<11, URLPathFetcher(nil))), APICredentials{})
+	if _, err := a.CorpMemberTracking(true); err == nil {
+		t.Error("Extended member tracking was allowed with the limited bit.")
+	}
+	if err := a.checkAccess("corp/MemberTracking", url.Values{}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRefreshAccessBits(t *testing.T) {
+	api := NewAPI("", nil, keyInfoFetcher("Character", 2048, URLPathFetcher(map[string]string{"api/CallList": callListXML})))
+	a := NewCredentialedAPI(api, APICredentials{})
+	if err := a.checkAccess("char/MailMessages", url.Values{}); err != nil {
+		t.Error(err)
+	}
+	if err := api.RefreshAccessBits(); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.checkAccess("char/MailMessages", url.Values{}); err != nil {
+		t.Error(err)
+	}
+	if err := a.checkAccess("char/WalletJournal", url.Values{}); err == nil {
+		t.Error("char/WalletJournal was allowed without its bit.")
+	}
+	if err := a.checkAccess("char/AssetList", url.Values{}); err != nil {
+		t.Errorf("Call missing from the call list was checked: %v", err)
+	}
+}
+
+func TestCharacterValidation(t *testing.T) {
+	var calls []string
+	fetcher := URLPathFetcher(map[string]string{"char/MailingLists": `<rowset name="mailingLists" key="listID" columns="listID,displayName" />`})
+	a := NewCredentialedAPI(NewAPI("", nil, keyInfoFetcher("Account", 1<<10, func(path string, params url.Values) (*http.Response, error) {
+		calls = append(calls, path)
+		return fetcher(path, params)
+	})), APICredentials{})
+
+	_, err := a.CharMailingLists(12345)
+	if denied, ok := err.(*ErrAccessDenied); !ok || denied.CharacterId != 12345 {
+		t.Errorf("Expected *ErrAccessDenied for character 12345, got %v", err)
+	}
+	if _, err := a.Character(12345); err == nil {
+		t.Error("Sub-client created for a character the key does not cover.")
+	}
+	chars, err := a.Characters()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chars) != 1 || chars[0].Id != 898901870 || chars[0].Name != "Desmont McCallock" {
+		t.Fatalf("Wrong characters: %+v", chars)
+	}
+	if _, err := chars[0].MailingLists(); err != nil {
+		t.Error(err)
+	}
+	if len(calls) != 1 {
+		t.Errorf("Expected one call to reach the server, got %v", calls)
+	}
+	if _, err := a.CorporationId(); err == nil {
+		t.Error("Account key reported a corporation.")
+	}
+}
+
+func TestCharacterValidationWithoutBit(t *testing.T) {
+	var calls []string
+	a := NewCredentialedAPI(NewAPI("", nil, keyInfoFetcher("Character", 0, func(path string, params url.Values) (*http.Response, error) {
+		calls = append(calls, path)
+		return URLErrFetcher(path, params)
+	})), APICredentials{})
+	_, err := a.CharacterInfo(12345)
+	if denied, ok := err.(*ErrAccessDenied); !ok || denied.CharacterId != 12345 {
+		t.Errorf("Expected *ErrAccessDenied for character 12345, got %v", err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("Call for a foreign character reached the server: %v", calls)
+	}
+}
+
+func TestCorporationId(t *testing.T) {
+	a := NewCredentialedAPI(NewAPI("", nil, keyInfoFetcher("Corporation", 0, URLPathFetcher(nil))), APICredentials{})
+	if id, err := a.CorporationId(); err != nil || id != 1000009 {
+		t.Errorf("Wrong corporation: %v, %v", id, err)
+	}
+}
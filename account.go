@@ -59,13 +59,16 @@ func (a *CredentialedAPI) AccountKeyInfo() (aKInfo, error) {
 		return r, err
 	}
 	result = result.Find("key")
+	if result == nil {
+		return r, fmt.Errorf("Unable to extract key from API response.")
+	}
 	if r.AccessMask, err = strconv.ParseInt(first(result.Get("accessMask")), 0, 64); err != nil {
 		return r, err
 	}
 	if r.Type, ok = key_types[first(result.Get("type"))]; ok == false {
 		return r, fmt.Errorf("No key type returned from API server.")
 	}
-	if temp, ok := result.Get("expires"); ok == false {
+	if temp, ok := result.Get("expires"); ok == false || temp == "" {
 		r.Expires = nil
 	} else {
 		temp, err := parseEveTs(temp)
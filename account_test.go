@@ -18,6 +18,14 @@ func (r apiTester) Get(path string, params url.Values, c *APICredentials) (t etr
 	return t.Item(0), nil
 }
 
+// Answers each call like apiTester with the document answer returns, so tests
+// can vary it by path or parameters.
+type apiFuncTester func(path string, params url.Values) string
+
+func (answer apiFuncTester) Get(path string, params url.Values, c *APICredentials) (etree.Element, error) {
+	return apiTester(answer(path, params)).Get(path, params, c)
+}
+
 func TestStatus(t *testing.T) {
 	a := NewCredentialedAPI(apiTester(statusXML), APICredentials{})
 	status, err := a.AccountStatus()
@@ -8,9 +8,13 @@ import (
 	"net/http"
 	"net/url"
 	"sort"
+	"strconv"
 	"time"
 )
 
+// Cache duration for data that never changes, such as names and mail bodies.
+const cacheForever = 100 * 365 * 24 * time.Hour
+
 type APIFetcher interface {
 	Get(path string, params url.Values, c *APICredentials) (etree.Element, error)
 }
@@ -20,11 +24,24 @@ type API struct {
 	Cache                            APICache
 	lastCachedUntil, lastCurrentTime time.Time
 	Client                           URLFetcher
+	// Names resolved by Resolve, ResolveTypes and ResolveNames. They never
+	// change, so they are kept far longer than API responses.
+	Names APICache
+	// Reference data from RefTypes, SkillTree and ConquerableStations, kept
+	// for days rather than until the server's cachedUntil.
+	Static APICache
+	// Returns the security status of a solar system for FormatKillMail, as
+	// the API itself does not report it. If unset, the Security line is left
+	// out.
+	SystemSecurity func(solarSystemId int64) (float64, error)
+	static         staticIndex
+	accessBits     map[string]int64
 }
 
 type CredentialedAPI struct {
 	api         APIFetcher
 	credentials APICredentials
+	keyInfo     *aKInfo
 }
 
 // Returns a new API object, filling in defaults as needed.
@@ -38,17 +55,55 @@ func NewAPI(base string, c APICache, uf URLFetcher) *API {
 	if uf == nil {
 		uf = http.PostForm
 	}
-	return &API{BaseURL: base, Cache: c, Client: uf}
+	return &API{BaseURL: base, Cache: c, Client: uf, Names: make(InMemoryAPICache), Static: make(InMemoryAPICache)}
 }
 
 func NewCredentialedAPI(a APIFetcher, c APICredentials) *CredentialedAPI {
 	return &CredentialedAPI{api: a, credentials: c}
 }
 
+// Makes a call with the key's credentials. Calls the key's type or access mask
+// do not allow fail with an *ErrAccessDenied before any request is made.
 func (a *CredentialedAPI) Get(path string, params url.Values) (etree.Element, error) {
+	if err := a.checkAccess(path, params); err != nil {
+		return nil, err
+	}
 	return a.api.Get(path, params, &a.credentials)
 }
 
+// Like Get, also returning the server time reported by the response. The time
+// is zero if the fetcher is not an *API.
+func (a *CredentialedAPI) getResult(path string, params url.Values) (etree.Element, time.Time, error) {
+	api, ok := a.api.(*API)
+	if !ok {
+		result, err := a.Get(path, params)
+		return result, time.Time{}, err
+	}
+	if err := a.checkAccess(path, params); err != nil {
+		return nil, time.Time{}, err
+	}
+	return api.getResult(path, params, &a.credentials)
+}
+
+// Returns the cache of the underlying API, or nil if the fetcher is not an *API.
+func (a *CredentialedAPI) cache() APICache {
+	if api, ok := a.api.(*API); ok {
+		return api.Cache
+	}
+	return nil
+}
+
+// An error reported by the API server itself, as opposed to a transport or
+// parsing failure.
+type APIError struct {
+	Code    int64
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API reported error with code %v and message \"%v\"", e.Code, e.Message)
+}
+
 type APICredentials struct {
 	KeyID, VCode string
 }
@@ -83,8 +138,15 @@ func (c InMemoryAPICache) Put(k string, v []byte, duration time.Duration) {
 	c[k] = &InMemoryCacheValue{V: v, Expiration: time.Now().Add(duration)}
 }
 
-//Request a specific path from the EVE API.
+// Request a specific path from the EVE API.
 func (a *API) Get(path string, params url.Values, c *APICredentials) (etree.Element, error) {
+	result, _, err := a.getResult(path, params, c)
+	return result, err
+}
+
+// Like Get, also returning the server time reported by this response, which
+// may be older than the time of the last request if it came from the cache.
+func (a *API) getResult(path string, params url.Values, c *APICredentials) (etree.Element, time.Time, error) {
 	if c != nil {
 		params["keyID"] = []string{c.KeyID}
 		params["vCode"] = []string{c.VCode}
@@ -95,36 +157,36 @@ func (a *API) Get(path string, params url.Values, c *APICredentials) (etree.Elem
 	if !cached {
 		r, err := a.Client(fmt.Sprintf("https://%v/%v.xml.aspx", a.BaseURL, path), params)
 		if err != nil {
-			return nil, err
+			return nil, time.Time{}, err
 		}
 		defer r.Body.Close()
 		response, err = ioutil.ReadAll(r.Body)
 		if err != nil {
-			return nil, err
+			return nil, time.Time{}, err
 		}
 	}
 
 	tree, err := etree.Parse(bytes.NewBuffer(response))
 	if err != nil {
-		return nil, err
+		return nil, time.Time{}, err
 	}
 	tree = tree.Find("eveapi")
 	elem := tree.Find("currentTime")
 	if elem == nil {
-		return nil, fmt.Errorf("Unable to parse currentTime.")
+		return nil, time.Time{}, fmt.Errorf("Unable to parse currentTime.")
 	}
 	currentTime, err := parseEveTs(elem.Text())
 	if err != nil {
-		return nil, err
+		return nil, time.Time{}, err
 	}
 	a.lastCurrentTime = currentTime
 	elem = tree.Find("cachedUntil")
 	if elem == nil {
-		return nil, fmt.Errorf("Unable to parse cachedUntil.")
+		return nil, time.Time{}, fmt.Errorf("Unable to parse cachedUntil.")
 	}
 	expiresTime, err := parseEveTs(elem.Text())
 	if err != nil {
-		return nil, err
+		return nil, time.Time{}, err
 	}
 	a.lastCachedUntil = expiresTime
 
@@ -134,11 +196,11 @@ func (a *API) Get(path string, params url.Values, c *APICredentials) (etree.Elem
 
 	xmlErr := tree.Find("error")
 	if xmlErr != nil {
-		code, _ := xmlErr.Get("code")
-		return nil, fmt.Errorf("API reported error with code %v and message \"%v\"", code, xmlErr.Text())
+		code, _ := strconv.ParseInt(first(xmlErr.Get("code")), 0, 64)
+		return nil, currentTime, &APIError{Code: code, Message: xmlErr.Text()}
 	}
 
-	return tree.Find("result"), nil
+	return tree.Find("result"), currentTime, nil
 }
 
 func genCacheKey(path string, params url.Values) string {
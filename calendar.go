@@ -0,0 +1,153 @@
+package golink
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type cCalendarEvent struct {
+	Id, OwnerId, OwnerTypeId int64
+	OwnerName                string
+	Date                     time.Time
+	Title, Text              string
+	Duration                 time.Duration
+	Important                bool
+	Response                 string
+}
+
+type cCalendarAttendee struct {
+	EventId, CharacterId int64
+	CharacterName        string
+	Response             string
+}
+
+func (a *CredentialedAPI) CharUpcomingCalendarEvents(charId int64) ([]cCalendarEvent, error) {
+	result, err := a.Get("char/UpcomingCalendarEvents", url.Values{"characterID": []string{formatId(charId)}})
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cCalendarEvent
+	for _, row := range rowset.FindAll("row") {
+		e := cCalendarEvent{}
+		if e.Id, err = getIntAttr(row, "eventID"); err != nil {
+			return nil, err
+		}
+		if e.OwnerId, err = getIntAttr(row, "ownerID"); err != nil {
+			return nil, err
+		}
+		e.OwnerName = first(row.Get("ownerName"))
+		if _, ok := row.Get("ownerTypeID"); ok {
+			if e.OwnerTypeId, err = getIntAttr(row, "ownerTypeID"); err != nil {
+				return nil, err
+			}
+		}
+		if e.Date, err = getTimeAttr(row, "eventDate"); err != nil {
+			return nil, err
+		}
+		e.Title = first(row.Get("eventTitle"))
+		e.Text = first(row.Get("eventText"))
+		minutes, err := getIntAttr(row, "duration")
+		if err != nil {
+			return nil, err
+		}
+		e.Duration = time.Duration(minutes) * time.Minute
+		e.Important = first(row.Get("importance")) == "1"
+		e.Response = first(row.Get("response"))
+		r = append(r, e)
+	}
+	return r, nil
+}
+
+func (a *CredentialedAPI) CharCalendarEventAttendees(charId int64, eventIds ...int64) ([]cCalendarAttendee, error) {
+	result, err := a.Get("char/CalendarEventAttendees", url.Values{"characterID": []string{formatId(charId)}, "eventIDs": []string{joinIds(eventIds)}})
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cCalendarAttendee
+	for _, row := range rowset.FindAll("row") {
+		at := cCalendarAttendee{}
+		if at.EventId, err = getIntAttr(row, "eventID"); err != nil {
+			return nil, err
+		}
+		if at.CharacterId, err = getIntAttr(row, "characterID"); err != nil {
+			return nil, err
+		}
+		at.CharacterName = first(row.Get("characterName"))
+		at.Response = first(row.Get("response"))
+		r = append(r, at)
+	}
+	return r, nil
+}
+
+// Renders events as an iCalendar (RFC 5545) document. EVE time is UTC, so all
+// times are written in UTC. stamp is used as the DTSTAMP of every event.
+func FormatICalendar(events []cCalendarEvent, stamp time.Time) string {
+	var b bytes.Buffer
+	writeICalLine(&b, "BEGIN:VCALENDAR")
+	writeICalLine(&b, "VERSION:2.0")
+	writeICalLine(&b, "PRODID:-//golink//EVE Calendar//EN")
+	writeICalLine(&b, "CALSCALE:GREGORIAN")
+	for _, e := range events {
+		writeICalLine(&b, "BEGIN:VEVENT")
+		writeICalLine(&b, fmt.Sprintf("UID:%v@calendar.eveonline.com", e.Id))
+		writeICalLine(&b, "DTSTAMP:"+formatICalTime(stamp))
+		writeICalLine(&b, "DTSTART:"+formatICalTime(e.Date))
+		if e.Duration > 0 {
+			writeICalLine(&b, fmt.Sprintf("DURATION:PT%vM", int64(e.Duration/time.Minute)))
+		}
+		writeICalLine(&b, "SUMMARY:"+escapeICalText(e.Title))
+		if e.Text != "" {
+			writeICalLine(&b, "DESCRIPTION:"+escapeICalText(e.Text))
+		}
+		if e.OwnerName != "" {
+			writeICalLine(&b, "X-EVE-OWNER:"+escapeICalText(e.OwnerName))
+		}
+		if e.Important {
+			writeICalLine(&b, "PRIORITY:1")
+		}
+		writeICalLine(&b, "END:VEVENT")
+	}
+	writeICalLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+func formatICalTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+func escapeICalText(s string) string {
+	return strings.NewReplacer("\\", "\\\\", ";", "\\;", ",", "\\,", "\r\n", "\\n", "\n", "\\n").Replace(s)
+}
+
+// Writes a content line, folding it so no line exceeds 75 octets without
+// splitting a UTF-8 sequence.
+func writeICalLine(b *bytes.Buffer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > limit-utf8.UTFMax && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		if !utf8.RuneStart(line[cut]) {
+			cut = limit // Invalid UTF-8; split anywhere.
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // Continuation lines start with a space.
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
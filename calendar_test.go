@@ -0,0 +1,96 @@
+package golink
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUpcomingCalendarEvents(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(upcomingEventsXML), APICredentials{})
+	events, err := a.CharUpcomingCalendarEvents(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Wrong number of events. Got %+v", events)
+	}
+	expected := cCalendarEvent{Id: 93264, OwnerId: 1, Date: time.Unix(1301130000, 0).UTC(), Title: "EVE Online Fanfest 2011",
+		Text: `Join us for <a href="http://fanfest.eveonline.com/">Fanfest</a>.`, Duration: 0, Important: false, Response: "Undecided"}
+	if events[0] != expected {
+		t.Errorf("Wrong event returned. Got %+v", events[0])
+	}
+	if events[1].Duration != 90*time.Minute || !events[1].Important || events[1].OwnerTypeId != 2 {
+		t.Errorf("Wrong event returned. Got %+v", events[1])
+	}
+}
+
+func TestCalendarEventAttendees(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(eventAttendeesXML), APICredentials{})
+	attendees, err := a.CharCalendarEventAttendees(1, 93264, 93265)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attendees) != 2 || attendees[1] != (cCalendarAttendee{EventId: 93265, CharacterId: 123456790, CharacterName: "Jane", Response: "Accepted"}) {
+		t.Errorf("Wrong attendees returned. Got %+v", attendees)
+	}
+}
+
+func TestFormatICalendar(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(upcomingEventsXML), APICredentials{})
+	events, err := a.CharUpcomingCalendarEvents(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ical := FormatICalendar(events[1:], time.Unix(1300000000, 0))
+	expected := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//golink//EVE Calendar//EN",
+		"CALSCALE:GREGORIAN",
+		"BEGIN:VEVENT",
+		"UID:93265@calendar.eveonline.com",
+		"DTSTAMP:20110313T070640Z",
+		"DTSTART:20110401T190000Z",
+		"DURATION:PT90M",
+		"SUMMARY:Op: form up\\, bring ships\\; no pods",
+		"DESCRIPTION:Line one\\nLine two with a very long text that needs to be folde",
+		" d onto a continuation line",
+		"X-EVE-OWNER:Some Corp",
+		"PRIORITY:1",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	if ical != expected {
+		t.Errorf("Wrong iCalendar output. Got:\n%v", ical)
+	}
+}
+
+const (
+	upcomingEventsXML = `
+<result>
+    <rowset name="upcomingEvents" key="eventID" columns="eventID,ownerID,ownerName,eventDate,eventTitle,duration,importance,response,eventText,ownerTypeID">
+        <row eventID="93264" ownerID="1" ownerName="" eventDate="2011-03-26 09:00:00" eventTitle="EVE Online Fanfest 2011" duration="0" importance="0" response="Undecided" eventText="Join us for &lt;a href=&quot;http://fanfest.eveonline.com/&quot;&gt;Fanfest&lt;/a&gt;." />
+        <row eventID="93265" ownerID="98000001" ownerName="Some Corp" eventDate="2011-04-01 19:00:00" eventTitle="Op: form up, bring ships; no pods" duration="90" importance="1" response="Accepted" eventText="Line one&#10;Line two with a very long text that needs to be folded onto a continuation line" ownerTypeID="2" />
+    </rowset>
+</result>
+`
+	eventAttendeesXML = `
+<result>
+    <rowset name="eventAttendees" key="eventID,characterID" columns="eventID,characterID,characterName,response">
+        <row eventID="93264" characterID="123456789" characterName="John" response="Undecided" />
+        <row eventID="93265" characterID="123456790" characterName="Jane" response="Accepted" />
+    </rowset>
+</result>
+`
+)
+
+func TestWriteICalLineInvalidUTF8(t *testing.T) {
+	var b bytes.Buffer
+	writeICalLine(&b, "SUMMARY:"+strings.Repeat("\x80", 100))
+	if lines := strings.Split(b.String(), "\r\n"); len(lines) != 3 || len(lines[0]) != 75 || len(lines[1]) != 34 {
+		t.Errorf("Wrong folding of invalid UTF-8. Got %q", b.String())
+	}
+}
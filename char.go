@@ -25,8 +25,13 @@ func handleAssetRowset(rowset etree.Element, locId int64) ([]cAsset, error) {
 		if asset.ItemTypeId, err = strconv.ParseInt(first(row.Get("typeID")), 0, 64); err != nil {
 			return ret, err
 		}
-		if asset.LocationId, err = strconv.ParseInt(first(row.Get("locationID")), 0, 64); err != nil {
-			return ret, err
+		// Nested items have no locationID of their own; they are wherever
+		// their container is.
+		asset.LocationId = locId
+		if temp, ok := row.Get("locationID"); ok {
+			if asset.LocationId, err = strconv.ParseInt(temp, 0, 64); err != nil {
+				return ret, err
+			}
 		}
 		if asset.LocationFlag, err = strconv.ParseInt(first(row.Get("flag")), 0, 64); err != nil {
 			return ret, err
@@ -36,7 +41,7 @@ func handleAssetRowset(rowset etree.Element, locId int64) ([]cAsset, error) {
 		}
 		asset.Packaged = first(row.Get("singleton")) == "0"
 		contents := row.Find("rowset")
-		if contents == nil {
+		if contents != nil {
 			asset.Contents, err = handleAssetRowset(contents, asset.LocationId)
 			if err != nil {
 				return ret, err
@@ -48,7 +53,7 @@ func handleAssetRowset(rowset etree.Element, locId int64) ([]cAsset, error) {
 }
 
 func (a *CredentialedAPI) CharAssets(charId int64) ([]cAsset, error) {
-	result, err := a.Get("char/AssetList", url.Values{"characterID": []string{string(charId)}})
+	result, err := a.Get("char/AssetList", url.Values{"characterID": []string{formatId(charId)}})
 	if err != nil {
 		return nil, err
 	}
@@ -60,12 +65,13 @@ func (a *CredentialedAPI) CharAssets(charId int64) ([]cAsset, error) {
 }
 
 type cContractBid struct {
-	Id, ContractId, BidderId, Amount int64
-	Timestamp                        time.Time
+	Id, ContractId, BidderId int64
+	Amount                   float64
+	Timestamp                time.Time
 }
 
 func (a *CredentialedAPI) CharContractBids(charId int64) ([]cContractBid, error) {
-	result, err := a.Get("char/ContractBids", url.Values{"characterID": []string{string(charId)}})
+	result, err := a.Get("char/ContractBids", url.Values{"characterID": []string{formatId(charId)}})
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +91,7 @@ func (a *CredentialedAPI) CharContractBids(charId int64) ([]cContractBid, error)
 		if c.BidderId, err = strconv.ParseInt(first(row.Get("bidderID")), 0, 64); err != nil {
 			return nil, err
 		}
-		if c.Amount, err = strconv.ParseInt(first(row.Get("amount")), 0, 64); err != nil {
+		if c.Amount, err = strconv.ParseFloat(first(row.Get("amount")), 64); err != nil {
 			return nil, err
 		}
 		if c.Timestamp, err = parseEveTs(first(row.Get("dateBid"))); err != nil {
@@ -103,7 +109,7 @@ type cContractItem struct {
 }
 
 func (a *CredentialedAPI) CharContractItems(charId int64, contractId int64) ([]cContractItem, error) {
-	result, err := a.Get("char/ContractItems", url.Values{"characterID": []string{string(charId)}, "contractId": []string{string(contractId)}})
+	result, err := a.Get("char/ContractItems", url.Values{"characterID": []string{formatId(charId)}, "contractID": []string{formatId(contractId)}})
 	if err != nil {
 		return nil, err
 	}
@@ -116,10 +122,10 @@ func (a *CredentialedAPI) CharContractItems(charId int64, contractId int64) ([]c
 	var ok bool
 	for _, row := range rowset.FindAll("row") {
 		c := cContractItem{}
-		if c.Id, err = strconv.ParseInt(first(row.Get("recordId")), 0, 64); err != nil {
+		if c.Id, err = strconv.ParseInt(first(row.Get("recordID")), 0, 64); err != nil {
 			return nil, err
 		}
-		if c.TypeId, err = strconv.ParseInt(first(row.Get("typeId")), 0, 64); err != nil {
+		if c.TypeId, err = strconv.ParseInt(first(row.Get("typeID")), 0, 64); err != nil {
 			return nil, err
 		}
 		if c.Quantity, err = strconv.ParseInt(first(row.Get("quantity")), 0, 64); err != nil {
@@ -142,3 +148,168 @@ func (a *CredentialedAPI) CharContractItems(charId int64, contractId int64) ([]c
 	}
 	return r, nil
 }
+
+type ContractType string
+
+const (
+	ContractItemExchange ContractType = "ItemExchange"
+	ContractCourier      ContractType = "Courier"
+	ContractAuction      ContractType = "Auction"
+	ContractLoan         ContractType = "Loan"
+)
+
+type ContractStatus string
+
+const (
+	ContractOutstanding           ContractStatus = "Outstanding"
+	ContractInProgress            ContractStatus = "InProgress"
+	ContractCompleted             ContractStatus = "Completed"
+	ContractCompletedByIssuer     ContractStatus = "CompletedByIssuer"
+	ContractCompletedByContractor ContractStatus = "CompletedByContractor"
+	ContractCancelled             ContractStatus = "Cancelled"
+	ContractRejected              ContractStatus = "Rejected"
+	ContractFailed                ContractStatus = "Failed"
+	ContractDeleted               ContractStatus = "Deleted"
+	ContractReversed              ContractStatus = "Reversed"
+)
+
+type ContractAvailability string
+
+const (
+	ContractPublic  ContractAvailability = "Public"
+	ContractPrivate ContractAvailability = "Private"
+)
+
+type cContract struct {
+	Id, IssuerId, IssuerCorpId, AssigneeId, AcceptorId int64
+	StartStationId, EndStationId                       int64
+	Type                                               ContractType
+	Status                                             ContractStatus
+	Availability                                       ContractAvailability
+	Title                                              string
+	ForCorp                                            bool
+	Issued, Expired, Accepted, Completed               time.Time
+	NumDays                                            int64
+	Price, Reward, Collateral, Buyout, Volume          float64
+}
+
+type cContractDetails struct {
+	Contract cContract
+	Items    []cContractItem
+	Bids     []cContractBid
+}
+
+func (a *CredentialedAPI) CharContracts(charId int64) ([]cContract, error) {
+	return a.contracts("char/Contracts", url.Values{"characterID": []string{formatId(charId)}})
+}
+
+func (a *CredentialedAPI) CorpContracts() ([]cContract, error) {
+	return a.contracts("corp/Contracts", url.Values{})
+}
+
+// Fetches a single contract together with its items and any bids on it.
+func (a *CredentialedAPI) CharContractDetails(charId int64, contractId int64) (cContractDetails, error) {
+	r := cContractDetails{}
+	contracts, err := a.contracts("char/Contracts", url.Values{"characterID": []string{formatId(charId)}, "contractID": []string{formatId(contractId)}})
+	if err != nil {
+		return r, err
+	}
+	found := false
+	for _, c := range contracts {
+		if c.Id == contractId {
+			r.Contract, found = c, true
+		}
+	}
+	if !found {
+		return r, fmt.Errorf("Contract %v not found.", contractId)
+	}
+	if r.Items, err = a.CharContractItems(charId, contractId); err != nil {
+		return r, err
+	}
+	if r.Contract.Type != ContractAuction {
+		return r, nil
+	}
+	bids, err := a.CharContractBids(charId)
+	if err != nil {
+		return r, err
+	}
+	for _, b := range bids {
+		if b.ContractId == contractId {
+			r.Bids = append(r.Bids, b)
+		}
+	}
+	return r, nil
+}
+
+func (a *CredentialedAPI) contracts(path string, params url.Values) ([]cContract, error) {
+	result, err := a.Get(path, params)
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cContract
+	for _, row := range rowset.FindAll("row") {
+		c := cContract{}
+		if c.Id, err = getIntAttr(row, "contractID"); err != nil {
+			return nil, err
+		}
+		if c.IssuerId, err = getIntAttr(row, "issuerID"); err != nil {
+			return nil, err
+		}
+		if c.IssuerCorpId, err = getIntAttr(row, "issuerCorpID"); err != nil {
+			return nil, err
+		}
+		if c.AssigneeId, err = getIntAttr(row, "assigneeID"); err != nil {
+			return nil, err
+		}
+		if c.AcceptorId, err = getIntAttr(row, "acceptorID"); err != nil {
+			return nil, err
+		}
+		if c.StartStationId, err = getIntAttr(row, "startStationID"); err != nil {
+			return nil, err
+		}
+		if c.EndStationId, err = getIntAttr(row, "endStationID"); err != nil {
+			return nil, err
+		}
+		c.Type = ContractType(first(row.Get("type")))
+		c.Status = ContractStatus(first(row.Get("status")))
+		c.Availability = ContractAvailability(first(row.Get("availability")))
+		c.Title = first(row.Get("title"))
+		c.ForCorp = first(row.Get("forCorp")) == "1"
+		if c.Issued, err = getOptTimeAttr(row, "dateIssued"); err != nil {
+			return nil, err
+		}
+		if c.Expired, err = getOptTimeAttr(row, "dateExpired"); err != nil {
+			return nil, err
+		}
+		if c.Accepted, err = getOptTimeAttr(row, "dateAccepted"); err != nil {
+			return nil, err
+		}
+		if c.Completed, err = getOptTimeAttr(row, "dateCompleted"); err != nil {
+			return nil, err
+		}
+		if c.NumDays, err = getIntAttr(row, "numDays"); err != nil {
+			return nil, err
+		}
+		if c.Price, err = getFloatAttr(row, "price"); err != nil {
+			return nil, err
+		}
+		if c.Reward, err = getFloatAttr(row, "reward"); err != nil {
+			return nil, err
+		}
+		if c.Collateral, err = getFloatAttr(row, "collateral"); err != nil {
+			return nil, err
+		}
+		if c.Buyout, err = getFloatAttr(row, "buyout"); err != nil {
+			return nil, err
+		}
+		if c.Volume, err = getFloatAttr(row, "volume"); err != nil {
+			return nil, err
+		}
+		r = append(r, c)
+	}
+	return r, nil
+}
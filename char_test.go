@@ -0,0 +1,80 @@
+package golink
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestContracts(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(contractsXML), APICredentials{})
+	contracts, err := a.CharContracts(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contracts) != 2 {
+		t.Fatalf("Wrong number of contracts. Got %+v", contracts)
+	}
+	expected := cContract{Id: 62205593, IssuerId: 1682304855, IssuerCorpId: 1018389948, AssigneeId: 0, AcceptorId: 0,
+		StartStationId: 60015027, EndStationId: 60015027, Type: ContractAuction, Status: ContractOutstanding,
+		Availability: ContractPublic, Title: "Auction", Issued: time.Unix(1355745599, 0).UTC(), Expired: time.Unix(1356350399, 0).UTC(),
+		NumDays: 0, Price: 500000, Buyout: 2000000, Volume: 10}
+	if contracts[0] != expected {
+		t.Errorf("Wrong contract returned. Got %+v", contracts[0])
+	}
+	if c := contracts[1]; c.Type != ContractCourier || !c.ForCorp || c.Reward != 1500000 || c.Collateral != 10000000 || c.Accepted.IsZero() {
+		t.Errorf("Wrong contract returned. Got %+v", c)
+	}
+}
+
+func TestContractDetails(t *testing.T) {
+	a := NewCredentialedAPI(apiFuncTester(func(path string, params url.Values) string {
+		return map[string]string{
+			"char/Contracts":     contractsXML,
+			"char/ContractItems": contractItemsXML,
+			"char/ContractBids":  contractBidsXML,
+		}[path]
+	}), APICredentials{})
+	d, err := a.CharContractDetails(1, 62205593)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Contract.Id != 62205593 {
+		t.Errorf("Wrong contract returned. Got %+v", d.Contract)
+	}
+	if len(d.Items) != 1 || d.Items[0] != (cContractItem{Id: 1737516979, TypeId: 4310, Quantity: 1, RawQuantity: -2, Action: "offered", Singleton: true}) {
+		t.Errorf("Wrong items returned. Got %+v", d.Items)
+	}
+	if len(d.Bids) != 1 || d.Bids[0].Id != 9 || d.Bids[0].Amount != 1000000.5 {
+		t.Errorf("Wrong bids returned. Got %+v", d.Bids)
+	}
+	if _, err := a.CharContractDetails(1, 123); err == nil {
+		t.Error("Expected an error for an unknown contract.")
+	}
+}
+
+const (
+	contractsXML = `
+<result>
+    <rowset name="contractList" key="contractID" columns="contractID,issuerID,issuerCorpID,assigneeID,acceptorID,startStationID,endStationID,type,status,title,forCorp,availability,dateIssued,dateExpired,dateAccepted,numDays,dateCompleted,price,reward,collateral,buyout,volume">
+        <row contractID="62205593" issuerID="1682304855" issuerCorpID="1018389948" assigneeID="0" acceptorID="0" startStationID="60015027" endStationID="60015027" type="Auction" status="Outstanding" title="Auction" forCorp="0" availability="Public" dateIssued="2012-12-17 11:59:59" dateExpired="2012-12-24 11:59:59" dateAccepted="" numDays="0" dateCompleted="" price="500000.00" reward="0.00" collateral="0.00" buyout="2000000.00" volume="10" />
+        <row contractID="62205594" issuerID="1682304855" issuerCorpID="1018389948" assigneeID="1018389948" acceptorID="198745217" startStationID="60015027" endStationID="60003760" type="Courier" status="InProgress" title="" forCorp="1" availability="Private" dateIssued="2012-12-17 11:59:59" dateExpired="2012-12-24 11:59:59" dateAccepted="2012-12-18 10:00:00" numDays="3" dateCompleted="" price="0.00" reward="1500000.00" collateral="10000000.00" buyout="0.00" volume="12500" />
+    </rowset>
+</result>
+`
+	contractItemsXML = `
+<result>
+    <rowset name="itemList" key="recordID" columns="recordID,typeID,quantity,rawQuantity,singleton,included">
+        <row recordID="1737516979" typeID="4310" quantity="1" rawQuantity="-2" singleton="1" included="1" />
+    </rowset>
+</result>
+`
+	contractBidsXML = `
+<result>
+    <rowset name="bidList" key="bidID" columns="bidID,contractID,bidderID,dateBid,amount">
+        <row bidID="9" contractID="62205593" bidderID="1682304855" dateBid="2012-12-18 11:00:00" amount="1000000.50" />
+        <row bidID="10" contractID="62205600" bidderID="1682304855" dateBid="2012-12-18 11:00:00" amount="50.00" />
+    </rowset>
+</result>
+`
+)
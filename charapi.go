@@ -0,0 +1,176 @@
+package golink
+
+import (
+	"fmt"
+	"sort"
+)
+
+// A CredentialedAPI bound to one of the key's characters, so character calls
+// need no character ID.
+type CharacterAPI struct {
+	aCharacter
+	api *CredentialedAPI
+}
+
+// Returns a sub-client for each character the key covers, ordered by ID.
+func (a *CredentialedAPI) Characters() ([]*CharacterAPI, error) {
+	k, err := a.KeyInfo()
+	if err != nil {
+		return nil, err
+	}
+	var ids []int
+	for id := range k.Characters {
+		ids = append(ids, int(id))
+	}
+	sort.Ints(ids)
+	var r []*CharacterAPI
+	for _, id := range ids {
+		r = append(r, &CharacterAPI{k.Characters[int64(id)], a})
+	}
+	return r, nil
+}
+
+// Returns a sub-client for one of the key's characters, or an
+// *ErrAccessDenied if the key does not cover it.
+func (a *CredentialedAPI) Character(charId int64) (*CharacterAPI, error) {
+	k, err := a.KeyInfo()
+	if err != nil {
+		return nil, err
+	}
+	c, ok := k.Characters[charId]
+	if !ok {
+		return nil, &ErrAccessDenied{KeyType: k.Type, AccessMask: k.AccessMask, CharacterId: charId}
+	}
+	return &CharacterAPI{c, a}, nil
+}
+
+// Returns the ID of the corporation a corporation key is for.
+func (a *CredentialedAPI) CorporationId() (int64, error) {
+	k, err := a.KeyInfo()
+	if err != nil {
+		return 0, err
+	}
+	if k.Type != "corp" {
+		return 0, fmt.Errorf("Key of type %v is not a corporation key.", k.Type)
+	}
+	for _, c := range k.Characters {
+		return c.CorpId, nil
+	}
+	return 0, fmt.Errorf("Unable to find the corporation of the key.")
+}
+
+func (c *CharacterAPI) Info() (eCharacterInfo, error) {
+	return c.api.CharacterInfo(c.Id)
+}
+
+func (c *CharacterAPI) Assets() ([]cAsset, error) {
+	return c.api.CharAssets(c.Id)
+}
+
+func (c *CharacterAPI) Contracts() ([]cContract, error) {
+	return c.api.CharContracts(c.Id)
+}
+
+func (c *CharacterAPI) ContractBids() ([]cContractBid, error) {
+	return c.api.CharContractBids(c.Id)
+}
+
+func (c *CharacterAPI) ContractItems(contractId int64) ([]cContractItem, error) {
+	return c.api.CharContractItems(c.Id, contractId)
+}
+
+func (c *CharacterAPI) ContractDetails(contractId int64) (cContractDetails, error) {
+	return c.api.CharContractDetails(c.Id, contractId)
+}
+
+func (c *CharacterAPI) UpcomingCalendarEvents() ([]cCalendarEvent, error) {
+	return c.api.CharUpcomingCalendarEvents(c.Id)
+}
+
+func (c *CharacterAPI) CalendarEventAttendees(eventIds ...int64) ([]cCalendarAttendee, error) {
+	return c.api.CharCalendarEventAttendees(c.Id, eventIds...)
+}
+
+func (c *CharacterAPI) IndustryJobs() ([]cIndustryJob, error) {
+	return c.api.CharIndustryJobs(c.Id)
+}
+
+func (c *CharacterAPI) IndustryJobsHistory() ([]cIndustryJob, error) {
+	return c.api.CharIndustryJobsHistory(c.Id)
+}
+
+func (c *CharacterAPI) KillMails(fromId int64) ([]cKillMail, error) {
+	return c.api.CharKillMails(c.Id, fromId)
+}
+
+func (c *CharacterAPI) AllKillMails() ([]cKillMail, error) {
+	return c.api.CharAllKillMails(c.Id)
+}
+
+func (c *CharacterAPI) MailMessages() ([]cMailMessage, error) {
+	return c.api.CharMailMessages(c.Id)
+}
+
+func (c *CharacterAPI) MailBodies(ids ...int64) (cMailBodies, error) {
+	return c.api.CharMailBodies(c.Id, ids...)
+}
+
+func (c *CharacterAPI) MailingLists() ([]cMailingList, error) {
+	return c.api.CharMailingLists(c.Id)
+}
+
+func (c *CharacterAPI) MarketOrders() ([]cMarketOrder, error) {
+	return c.api.CharMarketOrders(c.Id)
+}
+
+func (c *CharacterAPI) Notifications() ([]cNotification, error) {
+	return c.api.CharNotifications(c.Id)
+}
+
+func (c *CharacterAPI) NotificationTexts(ids ...int64) (cNotificationTexts, error) {
+	return c.api.CharNotificationTexts(c.Id, ids...)
+}
+
+func (c *CharacterAPI) PlanetaryColonies() ([]cPlanetaryColony, error) {
+	return c.api.CharPlanetaryColonies(c.Id)
+}
+
+func (c *CharacterAPI) PlanetaryPins(planetId int64) ([]cPlanetaryPin, error) {
+	return c.api.CharPlanetaryPins(c.Id, planetId)
+}
+
+func (c *CharacterAPI) PlanetaryRoutes(planetId int64) ([]cPlanetaryRoute, error) {
+	return c.api.CharPlanetaryRoutes(c.Id, planetId)
+}
+
+func (c *CharacterAPI) PlanetaryLinks(planetId int64) ([]cPlanetaryLink, error) {
+	return c.api.CharPlanetaryLinks(c.Id, planetId)
+}
+
+func (c *CharacterAPI) PlanetGraphs() ([]cPlanetGraph, error) {
+	return c.api.CharPlanetGraphs(c.Id)
+}
+
+func (c *CharacterAPI) Standings() (cNPCStandings, error) {
+	return c.api.CharStandings(c.Id)
+}
+
+func (c *CharacterAPI) ContactList() (cContactLists, error) {
+	return c.api.CharContactList(c.Id)
+}
+
+func (c *CharacterAPI) FacWarStats() (cFacWarStats, error) {
+	return c.api.CharFacWarStats(c.Id)
+}
+
+func (c *CharacterAPI) Medals() (cCharMedals, error) {
+	return c.api.CharMedals(c.Id)
+}
+
+func (c *CharacterAPI) Locations(ids ...int64) (map[int64]cLocation, error) {
+	return c.api.CharLocations(c.Id, ids...)
+}
+
+func (c *CharacterAPI) Bookmarks() ([]cBookmarkFolder, error) {
+	return c.api.CharBookmarks(c.Id)
+}
@@ -0,0 +1,311 @@
+package golink
+
+import (
+	"code.google.com/p/go-etree"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type eCorporationSheet struct {
+	Id                  int64
+	Name, Ticker        string
+	CeoId               int64
+	CeoName             string
+	StationId           int64
+	StationName         string
+	Description, URL    string
+	AllianceId          int64
+	AllianceName        string
+	FactionId           int64
+	TaxRate             float64
+	MemberCount, Shares int64
+}
+
+type eAllianceMember struct {
+	CorporationId int64
+	Start         time.Time
+}
+
+type eAlliance struct {
+	Id                 int64
+	Name, ShortName    string
+	ExecutorCorpId     int64
+	MemberCount        int64
+	Start              time.Time
+	MemberCorporations []eAllianceMember
+}
+
+type eCharacterAffiliation struct {
+	CharacterId     int64
+	CharacterName   string
+	CorporationId   int64
+	CorporationName string
+	AllianceId      int64
+	AllianceName    string
+	FactionId       int64
+	FactionName     string
+}
+
+type eEmploymentRecord struct {
+	RecordId, CorporationId int64
+	CorporationName         string
+	Start                   time.Time
+}
+
+// Character information. The fields after EmploymentHistory are only filled in
+// when fetched with a key that grants access to them; Authorized reports
+// whether that was the case.
+type eCharacterInfo struct {
+	CharacterId                     int64
+	CharacterName                   string
+	Race, Bloodline, Ancestry       string
+	CorporationId                   int64
+	CorporationName                 string
+	CorporationDate                 time.Time
+	AllianceId                      int64
+	AllianceName                    string
+	AllianceDate                    time.Time
+	SecurityStatus                  float64
+	EmploymentHistory               []eEmploymentRecord
+	Authorized                      bool
+	AccountBalance                  float64
+	SkillPoints                     int64
+	NextTrainingEnds                time.Time
+	ShipName                        string
+	ShipTypeId                      int64
+	ShipTypeName, LastKnownLocation string
+}
+
+// Returns the corporation, alliance and faction of each of the given
+// characters, keyed by character ID. Large lists are split into several calls.
+// This call needs no credentials.
+func (a *API) CharacterAffiliation(ids ...int64) (map[int64]eCharacterAffiliation, error) {
+	r := make(map[int64]eCharacterAffiliation)
+	for _, batch := range batchIds(uniqueIds(ids)) {
+		result, err := a.Get("eve/CharacterAffiliation", url.Values{"ids": []string{joinIds(batch)}}, nil)
+		if err != nil {
+			return nil, err
+		}
+		rowset := result.Find("rowset")
+		if rowset == nil {
+			return nil, fmt.Errorf("Unable to extract rowset from API response.")
+		}
+		for _, row := range rowset.FindAll("row") {
+			af := eCharacterAffiliation{}
+			if af.CharacterId, err = getIntAttr(row, "characterID"); err != nil {
+				return nil, err
+			}
+			af.CharacterName = first(row.Get("characterName"))
+			if af.CorporationId, err = getIntAttr(row, "corporationID"); err != nil {
+				return nil, err
+			}
+			af.CorporationName = first(row.Get("corporationName"))
+			if af.AllianceId, err = getIntAttr(row, "allianceID"); err != nil {
+				return nil, err
+			}
+			af.AllianceName = first(row.Get("allianceName"))
+			if af.FactionId, err = getIntAttr(row, "factionID"); err != nil {
+				return nil, err
+			}
+			af.FactionName = first(row.Get("factionName"))
+			r[af.CharacterId] = af
+		}
+	}
+	return r, nil
+}
+
+// Returns the public information about a character. This call needs no
+// credentials.
+func (a *API) CharacterInfo(charId int64) (eCharacterInfo, error) {
+	result, err := a.Get("eve/CharacterInfo", url.Values{"characterID": []string{formatId(charId)}}, nil)
+	if err != nil {
+		return eCharacterInfo{}, err
+	}
+	return parseCharacterInfo(result)
+}
+
+// Like API.CharacterInfo, but also returns the wallet, skill, ship and
+// location details the key grants access to.
+func (a *CredentialedAPI) CharacterInfo(charId int64) (eCharacterInfo, error) {
+	result, err := a.Get("eve/CharacterInfo", url.Values{"characterID": []string{formatId(charId)}})
+	if err != nil {
+		return eCharacterInfo{}, err
+	}
+	return parseCharacterInfo(result)
+}
+
+func parseCharacterInfo(result etree.Element) (eCharacterInfo, error) {
+	r := eCharacterInfo{}
+	var err error
+	if r.CharacterId, err = getIntValue(result, "characterID"); err != nil {
+		return r, err
+	}
+	r.CharacterName, _ = getStrValue(result, "characterName")
+	r.Race, _ = getStrValue(result, "race")
+	r.Bloodline, _ = getStrValue(result, "bloodline")
+	r.Ancestry, _ = getStrValue(result, "ancestry")
+	if r.CorporationId, err = getIntValue(result, "corporationID"); err != nil {
+		return r, err
+	}
+	r.CorporationName, _ = getStrValue(result, "corporation")
+	if r.CorporationDate, err = getTimeValue(result, "corporationDate"); err != nil {
+		return r, err
+	}
+	// Characters outside an alliance omit these entirely.
+	if result.Find("allianceID") != nil {
+		if r.AllianceId, err = getIntValue(result, "allianceID"); err != nil {
+			return r, err
+		}
+		r.AllianceName, _ = getStrValue(result, "alliance")
+		if r.AllianceDate, err = getTimeValue(result, "allianceDate"); err != nil {
+			return r, err
+		}
+	}
+	if r.SecurityStatus, err = getFloatValue(result, "securityStatus"); err != nil {
+		return r, err
+	}
+	if history := findRowset(result, "employmentHistory"); history != nil {
+		for _, row := range history.FindAll("row") {
+			e := eEmploymentRecord{}
+			if e.RecordId, err = getIntAttr(row, "recordID"); err != nil {
+				return r, err
+			}
+			if e.CorporationId, err = getIntAttr(row, "corporationID"); err != nil {
+				return r, err
+			}
+			e.CorporationName = first(row.Get("corporationName"))
+			if e.Start, err = getTimeAttr(row, "startDate"); err != nil {
+				return r, err
+			}
+			r.EmploymentHistory = append(r.EmploymentHistory, e)
+		}
+	}
+	// Wallet and skills need a key with access to them, ship and location a
+	// key with access to those as well; each group is omitted otherwise.
+	if result.Find("skillPoints") != nil {
+		r.Authorized = true
+		if r.AccountBalance, err = getFloatValue(result, "accountBalance"); err != nil {
+			return r, err
+		}
+		if r.SkillPoints, err = getIntValue(result, "skillPoints"); err != nil {
+			return r, err
+		}
+		if t, _ := getStrValue(result, "nextTrainingEnds"); t != "" {
+			if r.NextTrainingEnds, err = parseEveTs(t); err != nil {
+				return r, err
+			}
+		}
+	}
+	if result.Find("shipTypeID") != nil {
+		r.Authorized = true
+		r.ShipName, _ = getStrValue(result, "shipName")
+		if r.ShipTypeId, err = getIntValue(result, "shipTypeID"); err != nil {
+			return r, err
+		}
+		r.ShipTypeName, _ = getStrValue(result, "shipTypeName")
+		r.LastKnownLocation, _ = getStrValue(result, "lastKnownLocation")
+	}
+	return r, nil
+}
+
+// Returns the public information about a corporation. This call needs no
+// credentials.
+func (a *API) CorporationSheet(corpId int64) (eCorporationSheet, error) {
+	r := eCorporationSheet{}
+	result, err := a.Get("corp/CorporationSheet", url.Values{"corporationID": []string{formatId(corpId)}}, nil)
+	if err != nil {
+		return r, err
+	}
+	if r.Id, err = getIntValue(result, "corporationID"); err != nil {
+		return r, err
+	}
+	if r.Name, err = getStrValue(result, "corporationName"); err != nil {
+		return r, err
+	}
+	if r.Ticker, err = getStrValue(result, "ticker"); err != nil {
+		return r, err
+	}
+	if r.CeoId, err = getIntValue(result, "ceoID"); err != nil {
+		return r, err
+	}
+	r.CeoName, _ = getStrValue(result, "ceoName")
+	if r.StationId, err = getIntValue(result, "stationID"); err != nil {
+		return r, err
+	}
+	r.StationName, _ = getStrValue(result, "stationName")
+	r.Description, _ = getStrValue(result, "description")
+	r.URL, _ = getStrValue(result, "url")
+	// Corporations outside an alliance or faction omit these entirely.
+	if result.Find("allianceID") != nil {
+		if r.AllianceId, err = getIntValue(result, "allianceID"); err != nil {
+			return r, err
+		}
+		r.AllianceName, _ = getStrValue(result, "allianceName")
+	}
+	if result.Find("factionID") != nil {
+		if r.FactionId, err = getIntValue(result, "factionID"); err != nil {
+			return r, err
+		}
+	}
+	if r.TaxRate, err = getFloatValue(result, "taxRate"); err != nil {
+		return r, err
+	}
+	if r.MemberCount, err = getIntValue(result, "memberCount"); err != nil {
+		return r, err
+	}
+	if r.Shares, err = getIntValue(result, "shares"); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// Returns all alliances. The member corporations of each are only requested
+// if withMembers is set, as they make the response very large. This call
+// needs no credentials.
+func (a *API) AllianceList(withMembers bool) ([]eAlliance, error) {
+	params := url.Values{}
+	if !withMembers {
+		params["version"] = []string{"1"}
+	}
+	result, err := a.Get("eve/AllianceList", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []eAlliance
+	for _, row := range rowset.FindAll("row") {
+		al := eAlliance{}
+		if al.Id, err = getIntAttr(row, "allianceID"); err != nil {
+			return nil, err
+		}
+		al.Name = first(row.Get("name"))
+		al.ShortName = first(row.Get("shortName"))
+		if al.ExecutorCorpId, err = getIntAttr(row, "executorCorpID"); err != nil {
+			return nil, err
+		}
+		if al.MemberCount, err = getIntAttr(row, "memberCount"); err != nil {
+			return nil, err
+		}
+		if al.Start, err = getTimeAttr(row, "startDate"); err != nil {
+			return nil, err
+		}
+		if members := findRowset(row, "memberCorporations"); members != nil {
+			for _, mrow := range members.FindAll("row") {
+				m := eAllianceMember{}
+				if m.CorporationId, err = getIntAttr(mrow, "corporationID"); err != nil {
+					return nil, err
+				}
+				if m.Start, err = getTimeAttr(mrow, "startDate"); err != nil {
+					return nil, err
+				}
+				al.MemberCorporations = append(al.MemberCorporations, m)
+			}
+		}
+		r = append(r, al)
+	}
+	return r, nil
+}
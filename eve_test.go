@@ -0,0 +1,189 @@
+package golink
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestCorporationSheet(t *testing.T) {
+	a := NewAPI("", nil, URLPathFetcher(map[string]string{"corp/CorporationSheet": corporationSheetXML}))
+	sheet, err := a.CorporationSheet(150212025)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := eCorporationSheet{Id: 150212025, Name: "Banana Republic", Ticker: "BR", CeoId: 150208955, CeoName: "Mark Roled",
+		StationId: 60003469, StationName: "Jita IV - Caldari Business Tribunal Information Center", Description: "Garth's testing corp of awesome sauce, win sauce as it were. In this corp...<br><br>IT HAPPENS ALL OVER",
+		URL: "some url", AllianceId: 150430947, AllianceName: "The Dead Rabbits", TaxRate: 93.7, MemberCount: 3, Shares: 1}
+	if sheet != expected {
+		t.Errorf("Wrong corporation sheet. Got %+v", sheet)
+	}
+}
+
+func TestAllianceList(t *testing.T) {
+	var params url.Values
+	fetcher := URLPathFetcher(map[string]string{"eve/AllianceList": allianceListXML})
+	a := NewAPI("", nil, func(path string, p url.Values) (r *http.Response, err error) {
+		params = p
+		return fetcher(path, p)
+	})
+	alliances, err := a.AllianceList(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := params["version"]; ok {
+		t.Errorf("Member corporations were not requested. Params: %v", params)
+	}
+	if len(alliances) != 1 {
+		t.Fatalf("Wrong number of alliances. Got %+v", alliances)
+	}
+	al := alliances[0]
+	if al.Id != 824518128 || al.Name != "GoonSwarm" || al.ShortName != "OHGOD" || al.ExecutorCorpId != 1019941433 || al.MemberCount != 6050 || al.Start != time.Unix(1149295800, 0).UTC() {
+		t.Errorf("Wrong alliance returned. Got %+v", al)
+	}
+	if len(al.MemberCorporations) != 2 || al.MemberCorporations[1] != (eAllianceMember{CorporationId: 1019941433, Start: time.Unix(1149295800, 0).UTC()}) {
+		t.Errorf("Wrong member corporations returned. Got %+v", al.MemberCorporations)
+	}
+	if _, err := a.AllianceList(false); err != nil {
+		t.Fatal(err)
+	}
+	if params.Get("version") != "1" {
+		t.Errorf("Member corporations were requested. Params: %v", params)
+	}
+}
+
+func TestCharacterAffiliation(t *testing.T) {
+	calls := 0
+	fetcher := URLPathFetcher(map[string]string{"eve/CharacterAffiliation": characterAffiliationXML})
+	a := NewAPI("", nil, func(path string, p url.Values) (*http.Response, error) {
+		calls++
+		return fetcher(path, p)
+	})
+	ids := []int64{1643072492, 2112625428}
+	for i := int64(0); i < 300; i++ {
+		ids = append(ids, 90000000+i)
+	}
+	affiliations, err := a.CharacterAffiliation(ids...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls < 2 {
+		t.Errorf("IDs were not split into batches. Got %v calls.", calls)
+	}
+	expected := eCharacterAffiliation{CharacterId: 1643072492, CharacterName: "Catari Taga", CorporationId: 1000009, CorporationName: "Caldari Provisions",
+		FactionId: 500001, FactionName: "Caldari State"}
+	if affiliations[1643072492] != expected {
+		t.Errorf("Wrong affiliation. Got %+v", affiliations[1643072492])
+	}
+	if af := affiliations[2112625428]; af.AllianceId != 99000006 || af.AllianceName != "Everyshore Miners Alliance" || af.FactionId != 0 {
+		t.Errorf("Wrong affiliation. Got %+v", af)
+	}
+}
+
+func TestCharacterInfo(t *testing.T) {
+	a := NewAPI("", nil, URLPathFetcher(map[string]string{"eve/CharacterInfo": characterInfoXML}))
+	info, err := a.CharacterInfo(1643072492)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.CharacterId != 1643072492 || info.CharacterName != "Catari Taga" || info.Race != "Caldari" || info.Bloodline != "Achura" || info.Ancestry != "Monks" {
+		t.Errorf("Wrong character. Got %+v", info)
+	}
+	if info.CorporationId != 1000009 || info.CorporationName != "Caldari Provisions" || info.CorporationDate != time.Date(2013, 4, 5, 14, 12, 0, 0, time.UTC) || info.AllianceId != 0 {
+		t.Errorf("Wrong corporation. Got %+v", info)
+	}
+	if info.SecurityStatus != 0.00323 || info.Authorized {
+		t.Errorf("Wrong security status or authorization. Got %+v", info)
+	}
+	if len(info.EmploymentHistory) != 2 || info.EmploymentHistory[1] != (eEmploymentRecord{RecordId: 12304851, CorporationId: 1000168, CorporationName: "Imperial Academy", Start: time.Date(2012, 12, 1, 11, 0, 0, 0, time.UTC)}) {
+		t.Errorf("Wrong employment history. Got %+v", info.EmploymentHistory)
+	}
+
+	// Credentialed lookups are limited to the key's own characters.
+	ca := NewCredentialedAPI(NewAPI("", nil, keyInfoFetcher("Character", 0, URLPathFetcher(map[string]string{"eve/CharacterInfo": characterInfoKeyXML}))), APICredentials{})
+	if info, err = ca.CharacterInfo(898901870); err != nil {
+		t.Fatal(err)
+	}
+	if !info.Authorized || info.AccountBalance != 1534.51 || info.SkillPoints != 3250832 || !info.NextTrainingEnds.IsZero() {
+		t.Errorf("Wrong wallet or skills. Got %+v", info)
+	}
+	if info.ShipName != "Catari Taga's Capsule" || info.ShipTypeId != 670 || info.ShipTypeName != "Capsule" || info.LastKnownLocation != "Jita" {
+		t.Errorf("Wrong ship or location. Got %+v", info)
+	}
+	if info.AllianceId != 99000006 || info.AllianceName != "Everyshore Miners Alliance" || info.AllianceDate != time.Date(2013, 5, 1, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("Wrong alliance. Got %+v", info)
+	}
+}
+
+const (
+	characterAffiliationXML = `
+<rowset name="characters" key="characterID" columns="characterID,characterName,corporationID,corporationName,allianceID,allianceName,factionID,factionName">
+    <row characterID="1643072492" characterName="Catari Taga" corporationID="1000009" corporationName="Caldari Provisions" allianceID="0" allianceName="" factionID="500001" factionName="Caldari State" />
+    <row characterID="2112625428" characterName="CCP Bartender" corporationID="98039253" corporationName="Bartender's Corp" allianceID="99000006" allianceName="Everyshore Miners Alliance" factionID="0" factionName="" />
+</rowset>
+`
+	characterInfoXML = `
+<characterID>1643072492</characterID>
+<characterName>Catari Taga</characterName>
+<race>Caldari</race>
+<bloodline>Achura</bloodline>
+<ancestry>Monks</ancestry>
+<corporationID>1000009</corporationID>
+<corporation>Caldari Provisions</corporation>
+<corporationDate>2013-04-05 14:12:00</corporationDate>
+<securityStatus>0.00323</securityStatus>
+<rowset name="employmentHistory" key="recordID" columns="recordID,corporationID,corporationName,startDate">
+    <row recordID="13402847" corporationID="1000009" corporationName="Caldari Provisions" startDate="2013-04-05 14:12:00" />
+    <row recordID="12304851" corporationID="1000168" corporationName="Imperial Academy" startDate="2012-12-01 11:00:00" />
+</rowset>
+`
+	characterInfoKeyXML = `
+<characterID>1643072492</characterID>
+<characterName>Catari Taga</characterName>
+<race>Caldari</race>
+<bloodline>Achura</bloodline>
+<ancestry>Monks</ancestry>
+<accountBalance>1534.51</accountBalance>
+<skillPoints>3250832</skillPoints>
+<nextTrainingEnds></nextTrainingEnds>
+<shipName>Catari Taga's Capsule</shipName>
+<shipTypeID>670</shipTypeID>
+<shipTypeName>Capsule</shipTypeName>
+<corporationID>98039253</corporationID>
+<corporation>Bartender's Corp</corporation>
+<corporationDate>2013-04-30 12:00:00</corporationDate>
+<allianceID>99000006</allianceID>
+<alliance>Everyshore Miners Alliance</alliance>
+<allianceDate>2013-05-01 00:00:00</allianceDate>
+<lastKnownLocation>Jita</lastKnownLocation>
+<securityStatus>0.00323</securityStatus>
+<rowset name="employmentHistory" key="recordID" columns="recordID,corporationID,corporationName,startDate" />
+`
+	corporationSheetXML = `
+<corporationID>150212025</corporationID>
+<corporationName>Banana Republic</corporationName>
+<ticker>BR</ticker>
+<ceoID>150208955</ceoID>
+<ceoName>Mark Roled</ceoName>
+<stationID>60003469</stationID>
+<stationName>Jita IV - Caldari Business Tribunal Information Center</stationName>
+<description>Garth's testing corp of awesome sauce, win sauce as it were. In this corp...&lt;br&gt;&lt;br&gt;IT HAPPENS ALL OVER</description>
+<url>some url</url>
+<allianceID>150430947</allianceID>
+<allianceName>The Dead Rabbits</allianceName>
+<taxRate>93.7</taxRate>
+<memberCount>3</memberCount>
+<shares>1</shares>
+`
+	allianceListXML = `
+<rowset name="alliances" key="allianceID" columns="name,shortName,allianceID,executorCorpID,memberCount,startDate">
+    <row name="GoonSwarm" shortName="OHGOD" allianceID="824518128" executorCorpID="1019941433" memberCount="6050" startDate="2006-06-03 00:50:00">
+        <rowset name="memberCorporations" key="corporationID" columns="corporationID,startDate">
+            <row corporationID="98000001" startDate="2009-07-28 21:56:00" />
+            <row corporationID="1019941433" startDate="2006-06-03 00:50:00" />
+        </rowset>
+    </row>
+</rowset>
+`
+)
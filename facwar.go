@@ -0,0 +1,259 @@
+package golink
+
+import (
+	"code.google.com/p/go-etree"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type eFacWarCounts struct {
+	Yesterday, LastWeek, Total int64
+}
+
+// Faction warfare statistics of a character or corporation. CurrentRank and
+// HighestRank are only set for characters, Pilots only for corporations.
+type cFacWarStats struct {
+	FactionId                int64
+	FactionName              string
+	Enlisted                 time.Time
+	CurrentRank, HighestRank int64
+	Pilots                   int64
+	Kills, VictoryPoints     eFacWarCounts
+}
+
+type eFacWarFaction struct {
+	FactionId                 int64
+	FactionName               string
+	Pilots, SystemsControlled int64
+	Kills, VictoryPoints      eFacWarCounts
+}
+
+type eFacWar struct {
+	FactionId   int64
+	FactionName string
+	AgainstId   int64
+	AgainstName string
+}
+
+type eFacWarStats struct {
+	Kills, VictoryPoints eFacWarCounts
+	Factions             []eFacWarFaction
+	Wars                 []eFacWar
+}
+
+type eFacWarTopEntry struct {
+	Id    int64
+	Name  string
+	Value int64
+}
+
+type eFacWarTopList struct {
+	KillsYesterday, KillsLastWeek, KillsTotal                         []eFacWarTopEntry
+	VictoryPointsYesterday, VictoryPointsLastWeek, VictoryPointsTotal []eFacWarTopEntry
+}
+
+type eFacWarTopStats struct {
+	Characters, Corporations, Factions eFacWarTopList
+}
+
+func (a *CredentialedAPI) CharFacWarStats(charId int64) (cFacWarStats, error) {
+	result, err := a.Get("char/FacWarStats", url.Values{"characterID": []string{formatId(charId)}})
+	if err != nil {
+		return cFacWarStats{}, err
+	}
+	r, err := parseFacWarStats(result)
+	if err != nil {
+		return r, err
+	}
+	if r.CurrentRank, err = getIntValue(result, "currentRank"); err != nil {
+		return r, err
+	}
+	if r.HighestRank, err = getIntValue(result, "highestRank"); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+func (a *CredentialedAPI) CorpFacWarStats() (cFacWarStats, error) {
+	result, err := a.Get("corp/FacWarStats", url.Values{})
+	if err != nil {
+		return cFacWarStats{}, err
+	}
+	r, err := parseFacWarStats(result)
+	if err != nil {
+		return r, err
+	}
+	if r.Pilots, err = getIntValue(result, "pilots"); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// Returns the game-wide faction warfare totals, per-faction statistics and
+// the wars between factions. This call needs no credentials.
+func (a *API) FacWarStats() (eFacWarStats, error) {
+	r := eFacWarStats{}
+	result, err := a.Get("eve/FacWarStats", url.Values{}, nil)
+	if err != nil {
+		return r, err
+	}
+	totals := result.Find("totals")
+	if totals == nil {
+		return r, fmt.Errorf("Unable to find faction warfare totals.")
+	}
+	if r.Kills, err = getFacWarCounts(totals, "kills"); err != nil {
+		return r, err
+	}
+	if r.VictoryPoints, err = getFacWarCounts(totals, "victoryPoints"); err != nil {
+		return r, err
+	}
+	factions, wars := findRowset(result, "factions"), findRowset(result, "factionWars")
+	if factions == nil || wars == nil {
+		return r, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	for _, row := range factions.FindAll("row") {
+		f := eFacWarFaction{}
+		if f.FactionId, err = getIntAttr(row, "factionID"); err != nil {
+			return r, err
+		}
+		f.FactionName = first(row.Get("factionName"))
+		if f.Pilots, err = getIntAttr(row, "pilots"); err != nil {
+			return r, err
+		}
+		if f.SystemsControlled, err = getIntAttr(row, "systemsControlled"); err != nil {
+			return r, err
+		}
+		if f.Kills, err = getFacWarCountsAttr(row, "kills"); err != nil {
+			return r, err
+		}
+		if f.VictoryPoints, err = getFacWarCountsAttr(row, "victoryPoints"); err != nil {
+			return r, err
+		}
+		r.Factions = append(r.Factions, f)
+	}
+	for _, row := range wars.FindAll("row") {
+		w := eFacWar{}
+		if w.FactionId, err = getIntAttr(row, "factionID"); err != nil {
+			return r, err
+		}
+		w.FactionName = first(row.Get("factionName"))
+		if w.AgainstId, err = getIntAttr(row, "againstID"); err != nil {
+			return r, err
+		}
+		w.AgainstName = first(row.Get("againstName"))
+		r.Wars = append(r.Wars, w)
+	}
+	return r, nil
+}
+
+// Returns the top characters, corporations and factions by kills and victory
+// points. This call needs no credentials.
+func (a *API) FacWarTopStats() (eFacWarTopStats, error) {
+	r := eFacWarTopStats{}
+	result, err := a.Get("eve/FacWarTopStats", url.Values{}, nil)
+	if err != nil {
+		return r, err
+	}
+	if r.Characters, err = parseFacWarTopList(result, "characters", "character"); err != nil {
+		return r, err
+	}
+	if r.Corporations, err = parseFacWarTopList(result, "corporations", "corporation"); err != nil {
+		return r, err
+	}
+	if r.Factions, err = parseFacWarTopList(result, "factions", "faction"); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+func parseFacWarStats(result etree.Element) (cFacWarStats, error) {
+	r := cFacWarStats{}
+	var err error
+	if r.FactionId, err = getIntValue(result, "factionID"); err != nil {
+		return r, err
+	}
+	r.FactionName, _ = getStrValue(result, "factionName")
+	if r.Enlisted, err = getTimeValue(result, "enlisted"); err != nil {
+		return r, err
+	}
+	if r.Kills, err = getFacWarCounts(result, "kills"); err != nil {
+		return r, err
+	}
+	if r.VictoryPoints, err = getFacWarCounts(result, "victoryPoints"); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// Reads the <prefix>Yesterday, <prefix>LastWeek and <prefix>Total children.
+func getFacWarCounts(e etree.Element, prefix string) (eFacWarCounts, error) {
+	r := eFacWarCounts{}
+	var err error
+	if r.Yesterday, err = getIntValue(e, prefix+"Yesterday"); err != nil {
+		return r, err
+	}
+	if r.LastWeek, err = getIntValue(e, prefix+"LastWeek"); err != nil {
+		return r, err
+	}
+	if r.Total, err = getIntValue(e, prefix+"Total"); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// Like getFacWarCounts, for attributes of a row.
+func getFacWarCountsAttr(e etree.Element, prefix string) (eFacWarCounts, error) {
+	r := eFacWarCounts{}
+	var err error
+	if r.Yesterday, err = getIntAttr(e, prefix+"Yesterday"); err != nil {
+		return r, err
+	}
+	if r.LastWeek, err = getIntAttr(e, prefix+"LastWeek"); err != nil {
+		return r, err
+	}
+	if r.Total, err = getIntAttr(e, prefix+"Total"); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// Parses the six named rowsets under the given section of eve/FacWarTopStats,
+// whose rows are keyed by <kind>ID and <kind>Name.
+func parseFacWarTopList(result etree.Element, section, kind string) (eFacWarTopList, error) {
+	r := eFacWarTopList{}
+	e := result.Find(section)
+	if e == nil {
+		return r, fmt.Errorf("Unable to find %v in faction warfare top stats.", section)
+	}
+	lists := []struct {
+		name, column string
+		dest         *[]eFacWarTopEntry
+	}{
+		{"KillsYesterday", "kills", &r.KillsYesterday},
+		{"KillsLastWeek", "kills", &r.KillsLastWeek},
+		{"KillsTotal", "kills", &r.KillsTotal},
+		{"VictoryPointsYesterday", "victoryPoints", &r.VictoryPointsYesterday},
+		{"VictoryPointsLastWeek", "victoryPoints", &r.VictoryPointsLastWeek},
+		{"VictoryPointsTotal", "victoryPoints", &r.VictoryPointsTotal},
+	}
+	for _, l := range lists {
+		rowset := findRowset(e, l.name)
+		if rowset == nil {
+			return r, fmt.Errorf("Unable to find rowset %v in %v.", l.name, section)
+		}
+		for _, row := range rowset.FindAll("row") {
+			entry := eFacWarTopEntry{}
+			var err error
+			if entry.Id, err = getIntAttr(row, kind+"ID"); err != nil {
+				return r, err
+			}
+			entry.Name = first(row.Get(kind + "Name"))
+			if entry.Value, err = getIntAttr(row, l.column); err != nil {
+				return r, err
+			}
+			*l.dest = append(*l.dest, entry)
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,151 @@
+package golink
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCharFacWarStats(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(charFacWarStatsXML), APICredentials{})
+	stats, err := a.CharFacWarStats(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := cFacWarStats{FactionId: 500001, FactionName: "Caldari State", Enlisted: time.Date(2008, 6, 10, 22, 10, 0, 0, time.UTC), CurrentRank: 4, HighestRank: 5,
+		Kills: eFacWarCounts{0, 3, 214}, VictoryPoints: eFacWarCounts{0, 1044, 9030}}
+	if stats != expected {
+		t.Errorf("Wrong stats. Got %+v", stats)
+	}
+}
+
+func TestCorpFacWarStats(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(corpFacWarStatsXML), APICredentials{})
+	stats, err := a.CorpFacWarStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.FactionId != 500001 || stats.Pilots != 6 || stats.Kills != (eFacWarCounts{1, 5, 12}) || stats.CurrentRank != 0 {
+		t.Errorf("Wrong stats. Got %+v", stats)
+	}
+}
+
+func TestFacWarStats(t *testing.T) {
+	a := NewAPI("", nil, URLPathFetcher(map[string]string{"eve/FacWarStats": facWarStatsXML}))
+	stats, err := a.FacWarStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Kills != (eFacWarCounts{9256, 71329, 2906597}) || stats.VictoryPoints != (eFacWarCounts{102586, 840412, 73442853}) {
+		t.Errorf("Wrong totals. Got %+v", stats)
+	}
+	if len(stats.Factions) != 2 || stats.Factions[1] != (eFacWarFaction{FactionId: 500004, FactionName: "Gallente Federation", Pilots: 1953, SystemsControlled: 82,
+		Kills: eFacWarCounts{1430, 9621, 406114}, VictoryPoints: eFacWarCounts{21348, 180440, 10232102}}) {
+		t.Errorf("Wrong factions. Got %+v", stats.Factions)
+	}
+	if len(stats.Wars) != 2 || stats.Wars[0] != (eFacWar{500001, "Caldari State", 500004, "Gallente Federation"}) {
+		t.Errorf("Wrong wars. Got %+v", stats.Wars)
+	}
+}
+
+func TestFacWarTopStats(t *testing.T) {
+	a := NewAPI("", nil, URLPathFetcher(map[string]string{"eve/FacWarTopStats": facWarTopStatsXML}))
+	stats, err := a.FacWarTopStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := stats.Characters
+	if len(c.KillsYesterday) != 2 || c.KillsYesterday[0] != (eFacWarTopEntry{673662188, "Val Erian", 451}) || len(c.VictoryPointsTotal) != 1 || c.VictoryPointsTotal[0].Value != 3007845 {
+		t.Errorf("Wrong character stats. Got %+v", c)
+	}
+	if len(stats.Corporations.KillsLastWeek) != 1 || stats.Corporations.KillsLastWeek[0] != (eFacWarTopEntry{1000180, "State Protectorate", 3694}) {
+		t.Errorf("Wrong corporation stats. Got %+v", stats.Corporations)
+	}
+	if len(stats.Factions.VictoryPointsYesterday) != 1 || stats.Factions.VictoryPointsYesterday[0] != (eFacWarTopEntry{500004, "Gallente Federation", 21348}) {
+		t.Errorf("Wrong faction stats. Got %+v", stats.Factions)
+	}
+}
+
+const (
+	charFacWarStatsXML = `
+<result>
+    <factionID>500001</factionID>
+    <factionName>Caldari State</factionName>
+    <enlisted>2008-06-10 22:10:00</enlisted>
+    <currentRank>4</currentRank>
+    <highestRank>5</highestRank>
+    <killsYesterday>0</killsYesterday>
+    <killsLastWeek>3</killsLastWeek>
+    <killsTotal>214</killsTotal>
+    <victoryPointsYesterday>0</victoryPointsYesterday>
+    <victoryPointsLastWeek>1044</victoryPointsLastWeek>
+    <victoryPointsTotal>9030</victoryPointsTotal>
+</result>
+`
+	corpFacWarStatsXML = `
+<result>
+    <factionID>500001</factionID>
+    <factionName>Caldari State</factionName>
+    <enlisted>2008-06-10 22:10:00</enlisted>
+    <pilots>6</pilots>
+    <killsYesterday>1</killsYesterday>
+    <killsLastWeek>5</killsLastWeek>
+    <killsTotal>12</killsTotal>
+    <victoryPointsYesterday>0</victoryPointsYesterday>
+    <victoryPointsLastWeek>0</victoryPointsLastWeek>
+    <victoryPointsTotal>0</victoryPointsTotal>
+</result>
+`
+	facWarStatsXML = `
+<totals>
+    <killsYesterday>9256</killsYesterday>
+    <killsLastWeek>71329</killsLastWeek>
+    <killsTotal>2906597</killsTotal>
+    <victoryPointsYesterday>102586</victoryPointsYesterday>
+    <victoryPointsLastWeek>840412</victoryPointsLastWeek>
+    <victoryPointsTotal>73442853</victoryPointsTotal>
+</totals>
+<rowset name="factions" key="factionID" columns="factionID,factionName,pilots,systemsControlled,killsYesterday,killsLastWeek,killsTotal,victoryPointsYesterday,victoryPointsLastWeek,victoryPointsTotal">
+    <row factionID="500001" factionName="Caldari State" pilots="2497" systemsControlled="89" killsYesterday="1779" killsLastWeek="13287" killsTotal="565137" victoryPointsYesterday="24880" victoryPointsLastWeek="200890" victoryPointsTotal="14519290" />
+    <row factionID="500004" factionName="Gallente Federation" pilots="1953" systemsControlled="82" killsYesterday="1430" killsLastWeek="9621" killsTotal="406114" victoryPointsYesterday="21348" victoryPointsLastWeek="180440" victoryPointsTotal="10232102" />
+</rowset>
+<rowset name="factionWars" key="factionID" columns="factionID,factionName,againstID,againstName">
+    <row factionID="500001" factionName="Caldari State" againstID="500004" againstName="Gallente Federation" />
+    <row factionID="500004" factionName="Gallente Federation" againstID="500001" againstName="Caldari State" />
+</rowset>
+`
+	facWarTopStatsXML = `
+<characters>
+    <rowset name="KillsYesterday" key="characterID" columns="characterID,characterName,kills">
+        <row characterID="673662188" characterName="Val Erian" kills="451" />
+        <row characterID="187452523" characterName="Kane Rizzel" kills="316" />
+    </rowset>
+    <rowset name="KillsLastWeek" key="characterID" columns="characterID,characterName,kills" />
+    <rowset name="KillsTotal" key="characterID" columns="characterID,characterName,kills" />
+    <rowset name="VictoryPointsYesterday" key="characterID" columns="characterID,characterName,victoryPoints" />
+    <rowset name="VictoryPointsLastWeek" key="characterID" columns="characterID,characterName,victoryPoints" />
+    <rowset name="VictoryPointsTotal" key="characterID" columns="characterID,characterName,victoryPoints">
+        <row characterID="673662188" characterName="Val Erian" victoryPoints="3007845" />
+    </rowset>
+</characters>
+<corporations>
+    <rowset name="KillsYesterday" key="corporationID" columns="corporationID,corporationName,kills" />
+    <rowset name="KillsLastWeek" key="corporationID" columns="corporationID,corporationName,kills">
+        <row corporationID="1000180" corporationName="State Protectorate" kills="3694" />
+    </rowset>
+    <rowset name="KillsTotal" key="corporationID" columns="corporationID,corporationName,kills" />
+    <rowset name="VictoryPointsYesterday" key="corporationID" columns="corporationID,corporationName,victoryPoints" />
+    <rowset name="VictoryPointsLastWeek" key="corporationID" columns="corporationID,corporationName,victoryPoints" />
+    <rowset name="VictoryPointsTotal" key="corporationID" columns="corporationID,corporationName,victoryPoints" />
+</corporations>
+<factions>
+    <rowset name="KillsYesterday" key="factionID" columns="factionID,factionName,kills" />
+    <rowset name="KillsLastWeek" key="factionID" columns="factionID,factionName,kills" />
+    <rowset name="KillsTotal" key="factionID" columns="factionID,factionName,kills" />
+    <rowset name="VictoryPointsYesterday" key="factionID" columns="factionID,factionName,victoryPoints">
+        <row factionID="500004" factionName="Gallente Federation" victoryPoints="21348" />
+    </rowset>
+    <rowset name="VictoryPointsLastWeek" key="factionID" columns="factionID,factionName,victoryPoints" />
+    <rowset name="VictoryPointsTotal" key="factionID" columns="factionID,factionName,victoryPoints" />
+</factions>
+`
+)
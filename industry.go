@@ -0,0 +1,201 @@
+package golink
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type Activity int64
+
+const (
+	ActivityManufacturing                 Activity = 1
+	ActivityResearchingTimeEfficiency     Activity = 3
+	ActivityResearchingMaterialEfficiency Activity = 4
+	ActivityCopying                       Activity = 5
+	ActivityReverseEngineering            Activity = 7
+	ActivityInvention                     Activity = 8
+	ActivityReactions                     Activity = 11
+)
+
+var activityNames = map[Activity]string{
+	ActivityManufacturing:                 "manufacturing",
+	ActivityResearchingTimeEfficiency:     "researching time efficiency",
+	ActivityResearchingMaterialEfficiency: "researching material efficiency",
+	ActivityCopying:                       "copying",
+	ActivityReverseEngineering:            "reverse engineering",
+	ActivityInvention:                     "invention",
+	ActivityReactions:                     "reactions",
+}
+
+func (a Activity) String() string {
+	if n, ok := activityNames[a]; ok {
+		return n
+	}
+	return fmt.Sprintf("Activity(%d)", int64(a))
+}
+
+type JobStatus int64
+
+const (
+	JobActive    JobStatus = 1
+	JobPaused    JobStatus = 2
+	JobReady     JobStatus = 3
+	JobDelivered JobStatus = 101
+	JobCancelled JobStatus = 102
+	JobReverted  JobStatus = 103
+)
+
+var jobStatusNames = map[JobStatus]string{
+	JobActive:    "active",
+	JobPaused:    "paused",
+	JobReady:     "ready",
+	JobDelivered: "delivered",
+	JobCancelled: "cancelled",
+	JobReverted:  "reverted",
+}
+
+func (s JobStatus) String() string {
+	if n, ok := jobStatusNames[s]; ok {
+		return n
+	}
+	return fmt.Sprintf("JobStatus(%d)", int64(s))
+}
+
+type cIndustryJob struct {
+	Id, InstallerId                       int64
+	InstallerName                         string
+	FacilityId, SolarSystemId, StationId  int64
+	Activity                              Activity
+	BlueprintId, BlueprintTypeId          int64
+	BlueprintLocationId, OutputLocationId int64
+	Runs, LicensedRuns, SuccessfulRuns    int64
+	Cost, Probability                     float64
+	ProductTypeId                         int64
+	Status                                JobStatus
+	TimeInSeconds                         int64
+	Start, End, Pause, Completed          time.Time
+	CompletedCharacterId                  int64
+	// Server time of the response the job came from; zero if unknown.
+	ServerTime time.Time
+}
+
+func (a *CredentialedAPI) CharIndustryJobs(charId int64) ([]cIndustryJob, error) {
+	return a.industryJobs("char/IndustryJobs", url.Values{"characterID": []string{formatId(charId)}})
+}
+
+func (a *CredentialedAPI) CorpIndustryJobs() ([]cIndustryJob, error) {
+	return a.industryJobs("corp/IndustryJobs", url.Values{})
+}
+
+func (a *CredentialedAPI) CharIndustryJobsHistory(charId int64) ([]cIndustryJob, error) {
+	return a.industryJobs("char/IndustryJobsHistory", url.Values{"characterID": []string{formatId(charId)}})
+}
+
+func (a *CredentialedAPI) CorpIndustryJobsHistory() ([]cIndustryJob, error) {
+	return a.industryJobs("corp/IndustryJobsHistory", url.Values{})
+}
+
+// Returns the active jobs that end within d after now. Job end times are on
+// the server clock, so now should be too; a job's ServerTime is only as recent
+// as the possibly cached response it came from.
+func JobsFinishingWithin(jobs []cIndustryJob, now time.Time, d time.Duration) []cIndustryJob {
+	var r []cIndustryJob
+	for _, j := range jobs {
+		if j.Status == JobActive && j.End.After(now) && !j.End.After(now.Add(d)) {
+			r = append(r, j)
+		}
+	}
+	return r
+}
+
+func (a *CredentialedAPI) industryJobs(path string, params url.Values) ([]cIndustryJob, error) {
+	result, now, err := a.getResult(path, params)
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cIndustryJob
+	for _, row := range rowset.FindAll("row") {
+		j := cIndustryJob{ServerTime: now}
+		if j.Id, err = getIntAttr(row, "jobID"); err != nil {
+			return nil, err
+		}
+		if j.InstallerId, err = getIntAttr(row, "installerID"); err != nil {
+			return nil, err
+		}
+		j.InstallerName = first(row.Get("installerName"))
+		if j.FacilityId, err = getIntAttr(row, "facilityID"); err != nil {
+			return nil, err
+		}
+		if j.SolarSystemId, err = getIntAttr(row, "solarSystemID"); err != nil {
+			return nil, err
+		}
+		if j.StationId, err = getIntAttr(row, "stationID"); err != nil {
+			return nil, err
+		}
+		activity, err := getIntAttr(row, "activityID")
+		if err != nil {
+			return nil, err
+		}
+		j.Activity = Activity(activity)
+		if j.BlueprintId, err = getIntAttr(row, "blueprintID"); err != nil {
+			return nil, err
+		}
+		if j.BlueprintTypeId, err = getIntAttr(row, "blueprintTypeID"); err != nil {
+			return nil, err
+		}
+		if j.BlueprintLocationId, err = getIntAttr(row, "blueprintLocationID"); err != nil {
+			return nil, err
+		}
+		if j.OutputLocationId, err = getIntAttr(row, "outputLocationID"); err != nil {
+			return nil, err
+		}
+		if j.Runs, err = getIntAttr(row, "runs"); err != nil {
+			return nil, err
+		}
+		if j.LicensedRuns, err = getIntAttr(row, "licensedRuns"); err != nil {
+			return nil, err
+		}
+		if j.SuccessfulRuns, err = getIntAttr(row, "successfulRuns"); err != nil {
+			return nil, err
+		}
+		if j.Cost, err = getFloatAttr(row, "cost"); err != nil {
+			return nil, err
+		}
+		if j.Probability, err = getFloatAttr(row, "probability"); err != nil {
+			return nil, err
+		}
+		if j.ProductTypeId, err = getIntAttr(row, "productTypeID"); err != nil {
+			return nil, err
+		}
+		status, err := getIntAttr(row, "status")
+		if err != nil {
+			return nil, err
+		}
+		j.Status = JobStatus(status)
+		if j.TimeInSeconds, err = getIntAttr(row, "timeInSeconds"); err != nil {
+			return nil, err
+		}
+		if j.Start, err = getTimeAttr(row, "startDate"); err != nil {
+			return nil, err
+		}
+		if j.End, err = getTimeAttr(row, "endDate"); err != nil {
+			return nil, err
+		}
+		if j.Pause, err = getTimeAttr(row, "pauseDate"); err != nil {
+			return nil, err
+		}
+		if j.Completed, err = getTimeAttr(row, "completedDate"); err != nil {
+			return nil, err
+		}
+		if j.CompletedCharacterId, err = getIntAttr(row, "completedCharacterID"); err != nil {
+			return nil, err
+		}
+		r = append(r, j)
+	}
+	return r, nil
+}
@@ -0,0 +1,56 @@
+package golink
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIndustryJobs(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(industryJobsXML), APICredentials{})
+	jobs, err := a.CharIndustryJobs(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("Wrong number of jobs. Got %+v", jobs)
+	}
+	j := jobs[0]
+	if j.Id != 229136101 || j.Activity != ActivityManufacturing || j.Status != JobActive || j.FacilityId != 60006382 || j.Runs != 1 || j.Cost != 118.0 || j.End != time.Unix(1405288740, 0).UTC() {
+		t.Errorf("Wrong job returned. Got %+v", j)
+	}
+	if jobs[1].Activity.String() != "invention" || jobs[1].Status.String() != "paused" {
+		t.Errorf("Wrong enums returned. Got %v, %v", jobs[1].Activity, jobs[1].Status)
+	}
+
+	now := time.Unix(1405288740, 0).UTC().Add(-time.Hour)
+	if soon := JobsFinishingWithin(jobs, now, 2*time.Hour); len(soon) != 1 || soon[0].Id != 229136101 {
+		t.Errorf("Wrong jobs finishing soon. Got %+v", soon)
+	}
+	if soon := JobsFinishingWithin(jobs, now, 30*time.Minute); len(soon) != 0 {
+		t.Errorf("Wrong jobs finishing soon. Got %+v", soon)
+	}
+}
+
+func TestIndustryJobsServerTime(t *testing.T) {
+	api := NewAPI("", nil, keyInfoFetcher("Character", 1<<7, URLPathFetcher(map[string]string{
+		"char/IndustryJobs": industryJobsXML[strings.Index(industryJobsXML, "<rowset"):strings.Index(industryJobsXML, "</result>")],
+	})))
+	a := NewCredentialedAPI(api, APICredentials{})
+	jobs, err := a.CharIndustryJobs(898901870)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jobs[0].ServerTime != time.Date(2009, 10, 18, 17, 5, 31, 0, time.UTC) {
+		t.Errorf("Wrong server time. Got %v", jobs[0].ServerTime)
+	}
+}
+
+const industryJobsXML = `
+<result>
+    <rowset name="jobs" key="jobID" columns="jobID,installerID,installerName,facilityID,solarSystemID,solarSystemName,stationID,activityID,blueprintID,blueprintTypeID,blueprintTypeName,blueprintLocationID,outputLocationID,runs,cost,teamID,licensedRuns,probability,productTypeID,productTypeName,status,timeInSeconds,startDate,endDate,pauseDate,completedDate,completedCharacterID,successfulRuns">
+        <row jobID="229136101" installerID="498338451" installerName="Qoi" facilityID="60006382" solarSystemID="30005194" solarSystemName="Cleyd" stationID="60006382" activityID="1" blueprintID="1015116533326" blueprintTypeID="2047" blueprintTypeName="Damage Control I Blueprint" blueprintLocationID="60006382" outputLocationID="60006382" runs="1" cost="118.00" teamID="0" licensedRuns="200" probability="0" productTypeID="0" productTypeName="" status="1" timeInSeconds="548" startDate="2014-07-19 15:47:06" endDate="2014-07-13 21:59:00" pauseDate="0001-01-01 00:00:00" completedDate="0001-01-01 00:00:00" completedCharacterID="0" successfulRuns="0" />
+        <row jobID="229135883" installerID="498338451" installerName="Qoi" facilityID="60006382" solarSystemID="30005194" solarSystemName="Cleyd" stationID="60006382" activityID="8" blueprintID="1015321999447" blueprintTypeID="25862" blueprintTypeName="Salvager I Blueprint" blueprintLocationID="60006382" outputLocationID="60006382" runs="60" cost="1017.00" teamID="0" licensedRuns="600" probability="0.5" productTypeID="0" productTypeName="" status="2" timeInSeconds="31500" startDate="2014-07-19 13:40:59" endDate="2014-07-13 21:30:00" pauseDate="2014-07-13 20:00:00" completedDate="0001-01-01 00:00:00" completedCharacterID="0" successfulRuns="0" />
+    </rowset>
+</result>
+`
@@ -0,0 +1,214 @@
+package golink
+
+import (
+	"code.google.com/p/go-etree"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type cKillPilot struct {
+	CharacterId, CorporationId, AllianceId, FactionId         int64
+	CharacterName, CorporationName, AllianceName, FactionName string
+	ShipTypeId                                                int64
+}
+
+type cKillVictim struct {
+	cKillPilot
+	DamageTaken int64
+}
+
+type cKillAttacker struct {
+	cKillPilot
+	SecurityStatus float64
+	DamageDone     int64
+	FinalBlow      bool
+	WeaponTypeId   int64
+}
+
+type cKillItem struct {
+	TypeId, Flag, QtyDropped, QtyDestroyed int64
+	Singleton                              bool
+	Contents                               []cKillItem
+}
+
+type cKillMail struct {
+	Id, SolarSystemId, MoonId int64
+	Time                      time.Time
+	Victim                    cKillVictim
+	Attackers                 []cKillAttacker
+	Items                     []cKillItem
+}
+
+// Returns the kill mails of a character, newest first. If fromId is non-zero,
+// only kills older than that killID are returned.
+func (a *CredentialedAPI) CharKillMails(charId, fromId int64) ([]cKillMail, error) {
+	params := url.Values{"characterID": []string{formatId(charId)}}
+	if fromId != 0 {
+		params["fromID"] = []string{formatId(fromId)}
+	}
+	return a.killMails("char/KillMails", params)
+}
+
+// Returns the kill mails of the key's corporation, newest first. If fromId is
+// non-zero, only kills older than that killID are returned.
+func (a *CredentialedAPI) CorpKillMails(fromId int64) ([]cKillMail, error) {
+	params := url.Values{}
+	if fromId != 0 {
+		params["fromID"] = []string{formatId(fromId)}
+	}
+	return a.killMails("corp/KillMails", params)
+}
+
+// Walks back through all kill mails of a character available from the API.
+func (a *CredentialedAPI) CharAllKillMails(charId int64) ([]cKillMail, error) {
+	return walkKillMails(func(fromId int64) ([]cKillMail, error) { return a.CharKillMails(charId, fromId) })
+}
+
+// Walks back through all kill mails of the key's corporation available from
+// the API.
+func (a *CredentialedAPI) CorpAllKillMails() ([]cKillMail, error) {
+	return walkKillMails(a.CorpKillMails)
+}
+
+func walkKillMails(fetch func(fromId int64) ([]cKillMail, error)) ([]cKillMail, error) {
+	var r []cKillMail
+	var fromId int64
+	for {
+		page, err := fetch(fromId)
+		if err != nil {
+			return r, err
+		}
+		oldest := fromId
+		for _, k := range page {
+			if fromId != 0 && k.Id >= fromId {
+				continue
+			}
+			r = append(r, k)
+			if oldest == 0 || k.Id < oldest {
+				oldest = k.Id
+			}
+		}
+		if oldest == fromId {
+			return r, nil
+		}
+		fromId = oldest
+	}
+}
+
+func (a *CredentialedAPI) killMails(path string, params url.Values) ([]cKillMail, error) {
+	result, err := a.Get(path, params)
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cKillMail
+	for _, row := range rowset.FindAll("row") {
+		k := cKillMail{}
+		if k.Id, err = getIntAttr(row, "killID"); err != nil {
+			return nil, err
+		}
+		if k.SolarSystemId, err = getIntAttr(row, "solarSystemID"); err != nil {
+			return nil, err
+		}
+		if k.MoonId, err = getIntAttr(row, "moonID"); err != nil {
+			return nil, err
+		}
+		if k.Time, err = getTimeAttr(row, "killTime"); err != nil {
+			return nil, err
+		}
+		victim := row.Find("victim")
+		if victim == nil {
+			return nil, fmt.Errorf("Unable to find victim of kill %v.", k.Id)
+		}
+		if k.Victim.cKillPilot, err = parseKillPilot(victim); err != nil {
+			return nil, err
+		}
+		if k.Victim.DamageTaken, err = getIntAttr(victim, "damageTaken"); err != nil {
+			return nil, err
+		}
+		if attackers := findRowset(row, "attackers"); attackers != nil {
+			for _, arow := range attackers.FindAll("row") {
+				at := cKillAttacker{}
+				if at.cKillPilot, err = parseKillPilot(arow); err != nil {
+					return nil, err
+				}
+				if at.SecurityStatus, err = getFloatAttr(arow, "securityStatus"); err != nil {
+					return nil, err
+				}
+				if at.DamageDone, err = getIntAttr(arow, "damageDone"); err != nil {
+					return nil, err
+				}
+				at.FinalBlow = first(arow.Get("finalBlow")) == "1"
+				if at.WeaponTypeId, err = getIntAttr(arow, "weaponTypeID"); err != nil {
+					return nil, err
+				}
+				k.Attackers = append(k.Attackers, at)
+			}
+		}
+		if items := findRowset(row, "items"); items != nil {
+			if k.Items, err = handleKillItemRowset(items); err != nil {
+				return nil, err
+			}
+		}
+		r = append(r, k)
+	}
+	return r, nil
+}
+
+func parseKillPilot(e etree.Element) (cKillPilot, error) {
+	p := cKillPilot{}
+	var err error
+	if p.CharacterId, err = getIntAttr(e, "characterID"); err != nil {
+		return p, err
+	}
+	if p.CorporationId, err = getIntAttr(e, "corporationID"); err != nil {
+		return p, err
+	}
+	if p.AllianceId, err = getIntAttr(e, "allianceID"); err != nil {
+		return p, err
+	}
+	if p.FactionId, err = getIntAttr(e, "factionID"); err != nil {
+		return p, err
+	}
+	if p.ShipTypeId, err = getIntAttr(e, "shipTypeID"); err != nil {
+		return p, err
+	}
+	p.CharacterName = first(e.Get("characterName"))
+	p.CorporationName = first(e.Get("corporationName"))
+	p.AllianceName = first(e.Get("allianceName"))
+	p.FactionName = first(e.Get("factionName"))
+	return p, nil
+}
+
+func handleKillItemRowset(rowset etree.Element) ([]cKillItem, error) {
+	var ret []cKillItem
+	var err error
+	for _, row := range rowset.FindAll("row") {
+		item := cKillItem{}
+		if item.TypeId, err = getIntAttr(row, "typeID"); err != nil {
+			return ret, err
+		}
+		if item.Flag, err = getIntAttr(row, "flag"); err != nil {
+			return ret, err
+		}
+		if item.QtyDropped, err = getIntAttr(row, "qtyDropped"); err != nil {
+			return ret, err
+		}
+		if item.QtyDestroyed, err = getIntAttr(row, "qtyDestroyed"); err != nil {
+			return ret, err
+		}
+		singleton := first(row.Get("singleton"))
+		item.Singleton = singleton != "" && singleton != "0"
+		if contents := row.Find("rowset"); contents != nil {
+			if item.Contents, err = handleKillItemRowset(contents); err != nil {
+				return ret, err
+			}
+		}
+		ret = append(ret, item)
+	}
+	return ret, nil
+}
@@ -0,0 +1,192 @@
+package golink
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"sort"
+)
+
+// Item flags given their own suffix in the killmail text format. Fitted
+// modules have no suffix.
+var killMailFlagNames = map[int64]string{
+	5:  "Cargo",
+	87: "Drone Bay",
+	89: "Implant",
+}
+
+// Renders a kill mail in the classic in-game killmail text format accepted by
+// killboards. Names missing from the kill mail, along with ship, weapon, item,
+// solar system and moon names, are looked up with Resolve and ResolveTypes.
+// Solar system security comes from API.SystemSecurity; the Security line is
+// left out if that is unset.
+func (a *API) FormatKillMail(k cKillMail) (string, error) {
+	// Names are filled in on a copy so the caller's attackers are untouched.
+	k.Attackers = append([]cKillAttacker(nil), k.Attackers...)
+	pilots := []*cKillPilot{&k.Victim.cKillPilot}
+	for i := range k.Attackers {
+		pilots = append(pilots, &k.Attackers[i].cKillPilot)
+	}
+	var ownerIds, typeIds []int64
+	ownerIds = append(ownerIds, k.SolarSystemId, k.MoonId)
+	for _, p := range pilots {
+		if p.CharacterId != 0 && p.CharacterName == "" {
+			ownerIds = append(ownerIds, p.CharacterId)
+		}
+		if p.CorporationId != 0 && p.CorporationName == "" {
+			ownerIds = append(ownerIds, p.CorporationId)
+		}
+		if p.AllianceId != 0 && p.AllianceName == "" {
+			ownerIds = append(ownerIds, p.AllianceId)
+		}
+		typeIds = append(typeIds, p.ShipTypeId)
+	}
+	for _, at := range k.Attackers {
+		typeIds = append(typeIds, at.WeaponTypeId)
+	}
+	typeIds = appendKillItemTypes(typeIds, k.Items)
+
+	owners, err := a.Resolve(context.Background(), ownerIds)
+	if err != nil {
+		return "", err
+	}
+	types, err := a.ResolveTypes(context.Background(), typeIds)
+	if err != nil {
+		return "", err
+	}
+	for _, p := range pilots {
+		if p.CharacterName == "" {
+			p.CharacterName = owners[p.CharacterId]
+		}
+		if p.CorporationName == "" {
+			p.CorporationName = owners[p.CorporationId]
+		}
+		if p.AllianceName == "" {
+			p.AllianceName = owners[p.AllianceId]
+		}
+	}
+
+	var security *float64
+	if a.SystemSecurity != nil {
+		s, err := a.SystemSecurity(k.SolarSystemId)
+		if err != nil {
+			return "", err
+		}
+		security = &s
+	}
+
+	var b bytes.Buffer
+	v := k.Victim
+	fmt.Fprintf(&b, "%v\n\n", k.Time.Format("2006.01.02 15:04"))
+	// Structure kills have no victim pilot and start with the owning corp.
+	if v.CharacterId != 0 {
+		fmt.Fprintf(&b, "Victim: %v\n", v.CharacterName)
+	}
+	fmt.Fprintf(&b, "Corp: %v\n", orNone(v.CorporationName))
+	fmt.Fprintf(&b, "Alliance: %v\n", orNone(v.AllianceName))
+	fmt.Fprintf(&b, "Faction: %v\n", orNone(v.FactionName))
+	fmt.Fprintf(&b, "Destroyed: %v\n", types[v.ShipTypeId])
+	if k.MoonId != 0 {
+		fmt.Fprintf(&b, "Moon: %v\n", owners[k.MoonId])
+	}
+	fmt.Fprintf(&b, "System: %v\n", owners[k.SolarSystemId])
+	if security != nil {
+		fmt.Fprintf(&b, "Security: %.1f\n", *security)
+	}
+	fmt.Fprintf(&b, "Damage Taken: %v\n", v.DamageTaken)
+
+	b.WriteString("\nInvolved parties:\n\n")
+	for _, at := range k.Attackers {
+		if at.CharacterId == 0 {
+			fmt.Fprintf(&b, "Name: %v / %v\n", types[at.ShipTypeId], orNone(at.CorporationName))
+		} else if at.FinalBlow {
+			fmt.Fprintf(&b, "Name: %v (laid the final blow)\n", at.CharacterName)
+		} else {
+			fmt.Fprintf(&b, "Name: %v\n", at.CharacterName)
+		}
+		fmt.Fprintf(&b, "Security: %.1f\n", at.SecurityStatus)
+		fmt.Fprintf(&b, "Corp: %v\n", orNone(at.CorporationName))
+		fmt.Fprintf(&b, "Alliance: %v\n", orNone(at.AllianceName))
+		fmt.Fprintf(&b, "Faction: %v\n", orNone(at.FactionName))
+		fmt.Fprintf(&b, "Ship: %v\n", types[at.ShipTypeId])
+		fmt.Fprintf(&b, "Weapon: %v\n", types[at.WeaponTypeId])
+		fmt.Fprintf(&b, "Damage Done: %v\n\n", at.DamageDone)
+	}
+
+	var destroyed, dropped bytes.Buffer
+	writeKillItems(&destroyed, &dropped, k.Items, types, false)
+	if destroyed.Len() > 0 {
+		b.WriteString("Destroyed items:\n\n")
+		b.Write(destroyed.Bytes())
+		if dropped.Len() > 0 {
+			b.WriteString("\n")
+		}
+	}
+	if dropped.Len() > 0 {
+		b.WriteString("Dropped items:\n\n")
+		b.Write(dropped.Bytes())
+	}
+	return b.String(), nil
+}
+
+func writeKillItems(destroyed, dropped *bytes.Buffer, items []cKillItem, types map[int64]string, inContainer bool) {
+	for _, item := range items {
+		suffix := ""
+		if inContainer {
+			suffix = " (In Container)"
+		} else if name, ok := killMailFlagNames[item.Flag]; ok {
+			suffix = fmt.Sprintf(" (%v)", name)
+		}
+		if item.QtyDestroyed > 0 {
+			writeKillItem(destroyed, types[item.TypeId], item.QtyDestroyed, suffix)
+		}
+		if item.QtyDropped > 0 {
+			writeKillItem(dropped, types[item.TypeId], item.QtyDropped, suffix)
+		}
+		writeKillItems(destroyed, dropped, item.Contents, types, true)
+	}
+}
+
+func writeKillItem(b *bytes.Buffer, name string, qty int64, suffix string) {
+	if qty > 1 {
+		fmt.Fprintf(b, "%v, Qty: %v%v\n", name, qty, suffix)
+	} else {
+		fmt.Fprintf(b, "%v%v\n", name, suffix)
+	}
+}
+
+func appendKillItemTypes(ids []int64, items []cKillItem) []int64 {
+	for _, item := range items {
+		ids = append(ids, item.TypeId)
+		ids = appendKillItemTypes(ids, item.Contents)
+	}
+	return ids
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "None"
+	}
+	return s
+}
+
+// Returns a hash identifying a kill independently of who reported it, so the
+// same kill fetched through several characters or corporations can be
+// de-duplicated. Only IDs are hashed, so no names need to be resolved.
+func KillMailHash(k cKillMail) string {
+	var b bytes.Buffer
+	v := k.Victim
+	fmt.Fprintf(&b, "%v|%v|%v|%v|%v|%v\n", k.Time.UTC().Format("2006-01-02 15:04:05"), k.SolarSystemId, v.CharacterId, v.CorporationId, v.ShipTypeId, v.DamageTaken)
+	var attackers []string
+	for _, at := range k.Attackers {
+		attackers = append(attackers, fmt.Sprintf("%v|%v|%v|%v|%v|%v", at.CharacterId, at.CorporationId, at.ShipTypeId, at.WeaponTypeId, at.DamageDone, at.FinalBlow))
+	}
+	sort.Strings(attackers)
+	for _, s := range attackers {
+		fmt.Fprintln(&b, s)
+	}
+	h := sha1.Sum(b.Bytes())
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,165 @@
+package golink
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Serves eveapi documents by API path, wrapping each result body.
+func URLPathFetcher(results map[string]string) URLFetcher {
+	return func(path string, params url.Values) (*http.Response, error) {
+		body := ""
+		for k, v := range results {
+			if strings.HasSuffix(path, "/"+k+".xml.aspx") {
+				body = v
+			}
+		}
+		return &http.Response{Body: &nopCloser{bytes.NewBufferString(`<?xml version='1.0' encoding='UTF-8'?>
+<eveapi version="2">
+    <currentTime>2009-10-18 17:05:31</currentTime>
+    <result>` + body + `</result>
+    <cachedUntil>2009-10-18 18:05:31</cachedUntil>
+</eveapi>`)}}, nil
+	}
+}
+
+func TestFormatKillMail(t *testing.T) {
+	a := NewAPI("", nil, URLPathFetcher(map[string]string{
+		"eve/CharacterName": `<rowset name="characters" key="characterID" columns="name,characterID">
+<row name="Jita" characterID="30000848" /><row name="Some Alliance" characterID="150148475" /></rowset>`,
+		"eve/TypeName": `<rowset name="types" key="typeID" columns="typeID,typeName">
+<row typeID="24692" typeName="Abaddon" /><row typeID="17738" typeName="Machariel" />
+<row typeID="3475" typeName="Mega Pulse Laser II" /><row typeID="2488" typeName="Warrior II" />
+<row typeID="21097" typeName="Goru's Shuttle" /><row typeID="3467" typeName="Small Secure Container" />
+<row typeID="2410" typeName="Heavy Missile Launcher I" /></rowset>`,
+	}))
+	a.SystemSecurity = func(id int64) (float64, error) {
+		if id != 30000848 {
+			t.Errorf("Security requested for system %v", id)
+		}
+		return 0.946, nil
+	}
+	c := NewCredentialedAPI(apiTester(killMailsXML), APICredentials{})
+	kills, err := c.CharKillMails(1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kills[0].Attackers[1].AllianceName = ""
+	text, err := a.FormatKillMail(kills[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `2007.11.15 15:36
+
+Victim: Victim
+Corp: Center for Advanced Studies
+Alliance: None
+Faction: None
+Destroyed: Abaddon
+System: Jita
+Security: 0.9
+Damage Taken: 6057
+
+Involved parties:
+
+Name: Killer (laid the final blow)
+Security: -9.9
+Corp: Corp
+Alliance: Alliance
+Faction: None
+Ship: Abaddon
+Weapon: Mega Pulse Laser II
+Damage Done: 4057
+
+Name: Helper
+Security: 0.5
+Corp: Corp
+Alliance: Some Alliance
+Faction: None
+Ship: Machariel
+Weapon: Warrior II
+Damage Done: 2000
+
+Destroyed items:
+
+Small Secure Container (Cargo)
+Heavy Missile Launcher I, Qty: 50 (In Container)
+
+Dropped items:
+
+Goru's Shuttle
+`
+	if text != expected {
+		t.Errorf("Wrong killmail text. Got:\n%v", text)
+	}
+	if kills[0].Attackers[1].AllianceName != "" {
+		t.Error("FormatKillMail modified its argument.")
+	}
+}
+
+// Without API.SystemSecurity the Security line is left out rather than shown
+// as 0.0.
+func TestFormatMoonKillMail(t *testing.T) {
+	a := NewAPI("", nil, URLPathFetcher(map[string]string{
+		"eve/CharacterName": `<rowset name="characters" key="characterID" columns="name,characterID">
+<row name="Jita" characterID="30000848" /><row name="Jita IV - Moon 4" characterID="40009077" /></rowset>`,
+		"eve/TypeName": `<rowset name="types" key="typeID" columns="typeID,typeName">
+<row typeID="16213" typeName="Caldari Control Tower" /><row typeID="24692" typeName="Abaddon" /></rowset>`,
+	}))
+	k := cKillMail{Id: 1, SolarSystemId: 30000848, MoonId: 40009077, Time: time.Date(2007, 11, 15, 15, 36, 0, 0, time.UTC),
+		Victim: cKillVictim{cKillPilot{CorporationId: 1, CorporationName: "Owner Corp", ShipTypeId: 16213}, 5000000},
+		Attackers: []cKillAttacker{{cKillPilot: cKillPilot{CharacterId: 2, CharacterName: "Sieger", CorporationName: "Corp", ShipTypeId: 24692},
+			SecurityStatus: 1, DamageDone: 5000000, FinalBlow: true, WeaponTypeId: 24692}}}
+	text, err := a.FormatKillMail(k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `2007.11.15 15:36
+
+Corp: Owner Corp
+Alliance: None
+Faction: None
+Destroyed: Caldari Control Tower
+Moon: Jita IV - Moon 4
+System: Jita
+Damage Taken: 5000000
+
+Involved parties:
+
+Name: Sieger (laid the final blow)
+Security: 1.0
+Corp: Corp
+Alliance: None
+Faction: None
+Ship: Abaddon
+Weapon: Abaddon
+Damage Done: 5000000
+
+`
+	if text != expected {
+		t.Errorf("Wrong killmail text. Got:\n%v", text)
+	}
+}
+
+func TestKillMailHash(t *testing.T) {
+	c := NewCredentialedAPI(apiTester(killMailsXML), APICredentials{})
+	kills, err := c.CharKillMails(1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := kills[0]
+	h := KillMailHash(k)
+	k.Id = 64
+	k.Attackers = []cKillAttacker{k.Attackers[1], k.Attackers[0]}
+	if KillMailHash(k) != h {
+		t.Error("Hash depends on kill ID or attacker order.")
+	}
+	k.Victim.DamageTaken++
+	if KillMailHash(k) == h {
+		t.Error("Hash does not depend on damage taken.")
+	}
+}
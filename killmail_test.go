@@ -0,0 +1,85 @@
+package golink
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestKillMails(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(killMailsXML), APICredentials{})
+	kills, err := a.CharKillMails(1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kills) != 1 {
+		t.Fatalf("Wrong number of kills. Got %+v", kills)
+	}
+	k := kills[0]
+	if k.Id != 63 || k.SolarSystemId != 30000848 || k.Time != time.Unix(1195140960, 0).UTC() {
+		t.Errorf("Wrong kill returned. Got %+v", k)
+	}
+	if k.Victim.CharacterName != "Victim" || k.Victim.CorporationId != 1000169 || k.Victim.DamageTaken != 6057 || k.Victim.ShipTypeId != 24692 {
+		t.Errorf("Wrong victim returned. Got %+v", k.Victim)
+	}
+	if len(k.Attackers) != 2 || !k.Attackers[0].FinalBlow || k.Attackers[1].FinalBlow || k.Attackers[0].SecurityStatus != -9.9 || k.Attackers[0].WeaponTypeId != 3475 {
+		t.Errorf("Wrong attackers returned. Got %+v", k.Attackers)
+	}
+	if len(k.Items) != 2 || k.Items[0].QtyDropped != 1 || k.Items[1].Flag != 5 || !k.Items[1].Singleton {
+		t.Fatalf("Wrong items returned. Got %+v", k.Items)
+	}
+	if c := k.Items[1].Contents; len(c) != 1 || !reflect.DeepEqual(c[0], cKillItem{TypeId: 2410, Flag: 0, QtyDropped: 0, QtyDestroyed: 50}) {
+		t.Errorf("Wrong container contents returned. Got %+v", c)
+	}
+}
+
+func TestKillMailsWalk(t *testing.T) {
+	// Each page depends on the fromID parameter.
+	a := NewCredentialedAPI(apiFuncTester(func(path string, params url.Values) string {
+		return map[string]string{
+			"":    killPageXML(300, 200),
+			"200": killPageXML(150, 100),
+			"100": killPageXML(),
+		}[params.Get("fromID")]
+	}), APICredentials{})
+	kills, err := a.CorpAllKillMails()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kills) != 4 || kills[0].Id != 300 || kills[3].Id != 100 {
+		t.Errorf("Wrong kills walked. Got %+v", kills)
+	}
+}
+
+func killPageXML(ids ...int64) string {
+	s := `<result><rowset name="kills" key="killID" columns="killID,solarSystemID,killTime,moonID">`
+	for _, id := range ids {
+		s += `<row killID="` + formatId(id) + `" solarSystemID="1" killTime="2007-11-15 15:36:00" moonID="0">
+<victim characterID="1" characterName="" corporationID="2" corporationName="" allianceID="0" allianceName="" factionID="0" factionName="" damageTaken="1" shipTypeID="587" />
+</row>`
+	}
+	return s + `</rowset></result>`
+}
+
+const killMailsXML = `
+<result>
+    <rowset name="kills" key="killID" columns="killID,solarSystemID,killTime,moonID">
+        <row killID="63" solarSystemID="30000848" killTime="2007-11-15 15:36:00" moonID="0">
+            <victim characterID="150080271" characterName="Victim" corporationID="1000169" corporationName="Center for Advanced Studies" allianceID="0" allianceName="" factionID="0" factionName="" damageTaken="6057" shipTypeID="24692" />
+            <rowset name="attackers" columns="characterID,characterName,corporationID,corporationName,allianceID,allianceName,factionID,factionName,securityStatus,damageDone,finalBlow,weaponTypeID,shipTypeID">
+                <row characterID="150131146" characterName="Killer" corporationID="150147571" corporationName="Corp" allianceID="150148475" allianceName="Alliance" factionID="0" factionName="" securityStatus="-9.9" damageDone="4057" finalBlow="1" weaponTypeID="3475" shipTypeID="24692" />
+                <row characterID="150131147" characterName="Helper" corporationID="150147571" corporationName="Corp" allianceID="150148475" allianceName="Alliance" factionID="0" factionName="" securityStatus="0.5" damageDone="2000" finalBlow="0" weaponTypeID="2488" shipTypeID="17738" />
+            </rowset>
+            <rowset name="items" columns="typeID,flag,qtyDropped,qtyDestroyed,singleton">
+                <row typeID="21097" flag="0" qtyDropped="1" qtyDestroyed="0" singleton="0" />
+                <row typeID="3467" flag="5" qtyDropped="0" qtyDestroyed="1" singleton="1">
+                    <rowset name="items" columns="typeID,flag,qtyDropped,qtyDestroyed,singleton">
+                        <row typeID="2410" flag="0" qtyDropped="0" qtyDestroyed="50" singleton="0" />
+                    </rowset>
+                </row>
+            </rowset>
+        </row>
+    </rowset>
+</result>
+`
@@ -0,0 +1,174 @@
+package golink
+
+import (
+	"code.google.com/p/go-etree"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// The user-given name and position of an item. Position is only set for items
+// in space.
+type cLocation struct {
+	ItemId  int64
+	Name    string
+	X, Y, Z float64
+	MapId   int64
+	MapName string
+}
+
+type cBookmark struct {
+	Id, CreatorId  int64
+	Created        time.Time
+	ItemId, TypeId int64
+	LocationId     int64
+	X, Y, Z        float64
+	Memo, Note     string
+}
+
+type cBookmarkFolder struct {
+	Id        int64
+	Name      string
+	Bookmarks []cBookmark
+}
+
+// Returns the names and positions of the given items of a character, keyed by
+// itemID. Only assembled items can be looked up; see NameableAssetIds.
+func (a *CredentialedAPI) CharLocations(charId int64, ids ...int64) (map[int64]cLocation, error) {
+	return a.locations("char/Locations", url.Values{"characterID": []string{formatId(charId)}}, ids)
+}
+
+// Returns the names and positions of the given items of the key's
+// corporation, keyed by itemID.
+func (a *CredentialedAPI) CorpLocations(ids ...int64) (map[int64]cLocation, error) {
+	return a.locations("corp/Locations", url.Values{}, ids)
+}
+
+func (a *CredentialedAPI) CharBookmarks(charId int64) ([]cBookmarkFolder, error) {
+	return a.bookmarks("char/Bookmarks", url.Values{"characterID": []string{formatId(charId)}})
+}
+
+func (a *CredentialedAPI) CorpBookmarks() ([]cBookmarkFolder, error) {
+	return a.bookmarks("corp/Bookmarks", url.Values{})
+}
+
+// Returns the IDs of the assembled items in an asset tree, which are the ones
+// that can have a name.
+func NameableAssetIds(assets []cAsset) []int64 {
+	var r []int64
+	for _, asset := range assets {
+		if !asset.Packaged {
+			r = append(r, asset.Id)
+		}
+		r = append(r, NameableAssetIds(asset.Contents)...)
+	}
+	return r
+}
+
+func (a *CredentialedAPI) locations(path string, params url.Values, ids []int64) (map[int64]cLocation, error) {
+	r := make(map[int64]cLocation)
+	for _, batch := range batchIds(ids) {
+		batchParams := url.Values{"IDs": []string{joinIds(batch)}}
+		for k, v := range params {
+			batchParams[k] = v
+		}
+		result, err := a.Get(path, batchParams)
+		if err != nil {
+			return r, err
+		}
+		rowset := result.Find("rowset")
+		if rowset == nil {
+			return r, fmt.Errorf("Unable to extract rowset from API response.")
+		}
+		for _, row := range rowset.FindAll("row") {
+			l := cLocation{}
+			if l.ItemId, err = getIntAttr(row, "itemID"); err != nil {
+				return r, err
+			}
+			l.Name = first(row.Get("itemName"))
+			if l.X, err = getFloatAttr(row, "x"); err != nil {
+				return r, err
+			}
+			if l.Y, err = getFloatAttr(row, "y"); err != nil {
+				return r, err
+			}
+			if l.Z, err = getFloatAttr(row, "z"); err != nil {
+				return r, err
+			}
+			if temp := first(row.Get("mapID")); temp != "" {
+				if l.MapId, err = getIntAttr(row, "mapID"); err != nil {
+					return r, err
+				}
+			}
+			l.MapName = first(row.Get("mapName"))
+			r[l.ItemId] = l
+		}
+	}
+	return r, nil
+}
+
+func (a *CredentialedAPI) bookmarks(path string, params url.Values) ([]cBookmarkFolder, error) {
+	result, err := a.Get(path, params)
+	if err != nil {
+		return nil, err
+	}
+	rowset := findRowset(result, "folders")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cBookmarkFolder
+	for _, row := range rowset.FindAll("row") {
+		f := cBookmarkFolder{}
+		if f.Id, err = getIntAttr(row, "folderID"); err != nil {
+			return nil, err
+		}
+		f.Name = first(row.Get("folderName"))
+		if f.Bookmarks, err = parseBookmarks(findRowset(row, "bookmarks")); err != nil {
+			return nil, err
+		}
+		r = append(r, f)
+	}
+	return r, nil
+}
+
+func parseBookmarks(rowset etree.Element) ([]cBookmark, error) {
+	var r []cBookmark
+	if rowset == nil {
+		return r, nil
+	}
+	var err error
+	for _, row := range rowset.FindAll("row") {
+		b := cBookmark{}
+		if b.Id, err = getIntAttr(row, "bookmarkID"); err != nil {
+			return nil, err
+		}
+		if b.CreatorId, err = getIntAttr(row, "creatorID"); err != nil {
+			return nil, err
+		}
+		if b.Created, err = getTimeAttr(row, "created"); err != nil {
+			return nil, err
+		}
+		if b.ItemId, err = getIntAttr(row, "itemID"); err != nil {
+			return nil, err
+		}
+		if b.TypeId, err = getIntAttr(row, "typeID"); err != nil {
+			return nil, err
+		}
+		if b.LocationId, err = getIntAttr(row, "locationID"); err != nil {
+			return nil, err
+		}
+		if b.X, err = getFloatAttr(row, "x"); err != nil {
+			return nil, err
+		}
+		if b.Y, err = getFloatAttr(row, "y"); err != nil {
+			return nil, err
+		}
+		if b.Z, err = getFloatAttr(row, "z"); err != nil {
+			return nil, err
+		}
+		b.Memo = first(row.Get("memo"))
+		b.Note = first(row.Get("note"))
+		r = append(r, b)
+	}
+	return r, nil
+}
@@ -0,0 +1,111 @@
+package golink
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocations(t *testing.T) {
+	var calls []string
+	a := NewCredentialedAPI(apiFuncTester(func(path string, params url.Values) string {
+		calls = append(calls, params.Get("IDs"))
+		return locationsXML
+	}), APICredentials{})
+	ids := make([]int64, listMaxIds+1)
+	ids[0], ids[1] = 1004325577345, 1004325577346
+	for i := 2; i < len(ids); i++ {
+		ids[i] = int64(i)
+	}
+	locations, err := a.CharLocations(1, ids...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 2 || len(strings.Split(calls[0], ",")) != listMaxIds {
+		t.Errorf("IDs were not batched. Calls: %v", len(calls))
+	}
+	expected := cLocation{ItemId: 1004325577345, Name: "Catari's Raven", X: 1.25e11, Y: -3.5e10, Z: 7.75e11, MapId: 30000142, MapName: "Jita"}
+	if len(locations) != 2 || locations[1004325577345] != expected {
+		t.Errorf("Wrong locations. Got %+v", locations)
+	}
+	if l := locations[1004325577346]; l.Name != "Loot" || l.X != 0 || l.MapId != 0 {
+		t.Errorf("Wrong location for a docked item. Got %+v", l)
+	}
+}
+
+func TestNameableAssetIds(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(assetListXML), APICredentials{})
+	assets, err := a.CharAssets(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(assets) != 2 || len(assets[0].Contents) != 2 || assets[0].Contents[1].LocationId != 60003760 || len(assets[0].Contents[1].Contents) != 1 {
+		t.Fatalf("Wrong asset tree. Got %+v", assets)
+	}
+	if ids := NameableAssetIds(assets); !reflect.DeepEqual(ids, []int64{1004325577345, 1004325577346}) {
+		t.Errorf("Wrong nameable IDs. Got %v", ids)
+	}
+}
+
+func TestBookmarks(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(bookmarksXML), APICredentials{})
+	folders, err := a.CharBookmarks(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(folders) != 2 || folders[0].Id != 0 || folders[0].Name != "" || len(folders[0].Bookmarks) != 1 || len(folders[1].Bookmarks) != 0 {
+		t.Fatalf("Wrong folders. Got %+v", folders)
+	}
+	expected := cBookmark{Id: 12, CreatorId: 90000001, Created: time.Date(2015, 7, 8, 21, 34, 28, 0, time.UTC), ItemId: 0, TypeId: 5,
+		LocationId: 30003068, X: -373138830681.2, Y: -3.19e10, Z: 5.0e11, Memo: "spot", Note: "safe"}
+	if folders[0].Bookmarks[0] != expected {
+		t.Errorf("Wrong bookmark. Got %+v", folders[0].Bookmarks[0])
+	}
+	if folders[1].Name != "Moons" {
+		t.Errorf("Wrong folder. Got %+v", folders[1])
+	}
+}
+
+const (
+	locationsXML = `
+<result>
+    <rowset name="locations" key="itemID" columns="itemID,itemName,x,y,z,mapID,mapName">
+        <row itemID="1004325577345" itemName="Catari's Raven" x="1.25e11" y="-3.5e10" z="7.75e11" mapID="30000142" mapName="Jita" />
+        <row itemID="1004325577346" itemName="Loot" x="0" y="0" z="0" />
+    </rowset>
+</result>
+`
+	assetListXML = `
+<result>
+    <rowset name="assets" key="itemID" columns="itemID,locationID,typeID,quantity,flag,singleton">
+        <row itemID="1004325577345" locationID="60003760" typeID="638" quantity="1" flag="4" singleton="1">
+            <rowset name="contents" key="itemID" columns="itemID,typeID,quantity,flag,singleton">
+                <row itemID="1004325577400" typeID="2048" quantity="1" flag="27" singleton="0" />
+                <row itemID="1004325577346" typeID="3467" quantity="1" flag="5" singleton="1">
+                    <rowset name="contents" key="itemID" columns="itemID,typeID,quantity,flag,singleton">
+                        <row itemID="1004325577401" typeID="34" quantity="5000" flag="0" singleton="0" />
+                    </rowset>
+                </row>
+            </rowset>
+        </row>
+        <row itemID="1004325577402" locationID="60003760" typeID="34" quantity="100" flag="4" singleton="0" />
+    </rowset>
+</result>
+`
+	bookmarksXML = `
+<result>
+    <rowset name="folders" key="folderID" columns="folderID,folderName">
+        <row folderID="0" folderName="">
+            <rowset name="bookmarks" key="bookmarkID" columns="bookmarkID,creatorID,created,itemID,typeID,locationID,x,y,z,memo,note">
+                <row bookmarkID="12" creatorID="90000001" created="2015-07-08 21:34:28" itemID="0" typeID="5" locationID="30003068" x="-373138830681.2" y="-3.19e10" z="5.0e11" memo="spot" note="safe" />
+            </rowset>
+        </row>
+        <row folderID="1" folderName="Moons">
+            <rowset name="bookmarks" key="bookmarkID" columns="bookmarkID,creatorID,created,itemID,typeID,locationID,x,y,z,memo,note" />
+        </row>
+    </rowset>
+</result>
+`
+)
@@ -0,0 +1,137 @@
+package golink
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type cMailMessage struct {
+	Id, SenderId              int64
+	SenderName, Title         string
+	Sent                      time.Time
+	ToCorpOrAllianceId        int64
+	ToCharacterIds, ToListIds []int64
+}
+
+type cMailBodies struct {
+	Bodies  map[int64]string
+	Missing []int64
+}
+
+type cMailingList struct {
+	Id          int64
+	DisplayName string
+}
+
+func (a *CredentialedAPI) CharMailMessages(charId int64) ([]cMailMessage, error) {
+	result, err := a.Get("char/MailMessages", url.Values{"characterID": []string{formatId(charId)}})
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cMailMessage
+	for _, row := range rowset.FindAll("row") {
+		m := cMailMessage{}
+		if m.Id, err = getIntAttr(row, "messageID"); err != nil {
+			return nil, err
+		}
+		if m.SenderId, err = getIntAttr(row, "senderID"); err != nil {
+			return nil, err
+		}
+		m.SenderName = first(row.Get("senderName"))
+		m.Title = first(row.Get("title"))
+		if m.Sent, err = getTimeAttr(row, "sentDate"); err != nil {
+			return nil, err
+		}
+		if temp := first(row.Get("toCorpOrAllianceID")); temp != "" {
+			if m.ToCorpOrAllianceId, err = strconv.ParseInt(temp, 0, 64); err != nil {
+				return nil, err
+			}
+		}
+		if m.ToCharacterIds, err = parseIdList(first(row.Get("toCharacterIDs"))); err != nil {
+			return nil, err
+		}
+		if m.ToListIds, err = parseIdList(first(row.Get("toListID"))); err != nil {
+			return nil, err
+		}
+		r = append(r, m)
+	}
+	return r, nil
+}
+
+// Fetches the bodies of the given messages, splitting the IDs into batches the
+// API will accept. Bodies never change once sent, so they are cached forever
+// and bodies already in the API cache are not requested again.
+// IDs the server does not know about are reported in Missing.
+func (a *CredentialedAPI) CharMailBodies(charId int64, ids ...int64) (cMailBodies, error) {
+	r := cMailBodies{Bodies: make(map[int64]string)}
+	cache := a.cache()
+	var todo []int64
+	for _, id := range ids {
+		if cache != nil {
+			if body := cache.Get(a.mailBodyCacheKey(charId, id)); body != nil {
+				r.Bodies[id] = string(body)
+				continue
+			}
+		}
+		todo = append(todo, id)
+	}
+	for _, batch := range batchIds(todo) {
+		result, err := a.Get("char/MailBodies", url.Values{"characterID": []string{formatId(charId)}, "ids": []string{joinIds(batch)}})
+		if err != nil {
+			return r, err
+		}
+		if rowset := result.Find("rowset"); rowset != nil {
+			for _, row := range rowset.FindAll("row") {
+				id, err := getIntAttr(row, "messageID")
+				if err != nil {
+					return r, err
+				}
+				r.Bodies[id] = row.Text()
+				if cache != nil {
+					cache.Put(a.mailBodyCacheKey(charId, id), []byte(row.Text()), cacheForever)
+				}
+			}
+		}
+		if missing := result.Find("missingMessageIDs"); missing != nil {
+			m, err := parseIdList(missing.Text())
+			if err != nil {
+				return r, err
+			}
+			r.Missing = append(r.Missing, m...)
+		}
+	}
+	return r, nil
+}
+
+func (a *CredentialedAPI) mailBodyCacheKey(charId, id int64) string {
+	return genCacheKey("char/MailBodies#body", url.Values{"keyID": []string{a.credentials.KeyID}, "characterID": []string{formatId(charId)}, "messageID": []string{formatId(id)}})
+}
+
+func (a *CredentialedAPI) CharMailingLists(charId int64) ([]cMailingList, error) {
+	result, err := a.Get("char/MailingLists", url.Values{"characterID": []string{formatId(charId)}})
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cMailingList
+	for _, row := range rowset.FindAll("row") {
+		l := cMailingList{}
+		if l.Id, err = getIntAttr(row, "listID"); err != nil {
+			return nil, err
+		}
+		if l.DisplayName, err = getStrAttr(row, "displayName"); err != nil {
+			return nil, err
+		}
+		r = append(r, l)
+	}
+	return r, nil
+}
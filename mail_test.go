@@ -0,0 +1,111 @@
+package golink
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMailMessages(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(mailMessagesXML), APICredentials{})
+	msgs, err := a.CharMailMessages(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 2 {
+		t.Fatalf("Wrong number of messages. Got %+v", msgs)
+	}
+	m := msgs[0]
+	if m.Id != 290285276 || m.SenderId != 999999999 || m.Title != "Title 1" || !m.Sent.Equal(time.Unix(1259629440, 0)) || len(m.ToListIds) != 1 || m.ToListIds[0] != 128250439 {
+		t.Errorf("Wrong message returned. Got %+v", m)
+	}
+	m = msgs[1]
+	if m.ToCorpOrAllianceId != 1234 || len(m.ToCharacterIds) != 2 || m.ToCharacterIds[1] != 456 || len(m.ToListIds) != 0 {
+		t.Errorf("Wrong message returned. Got %+v", m)
+	}
+}
+
+func TestMailingLists(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(mailingListsXML), APICredentials{})
+	lists, err := a.CharMailingLists(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lists) != 2 || lists[1] != (cMailingList{Id: 128783669, DisplayName: "EVEMarketScanner"}) {
+		t.Errorf("Wrong mailing lists returned. Got %+v", lists)
+	}
+}
+
+func TestMailBodies(t *testing.T) {
+	var calls []url.Values
+	fetcher := func(path string, params url.Values) (*http.Response, error) {
+		calls = append(calls, params)
+		return &http.Response{Body: &nopCloser{bytes.NewBufferString(mailBodiesXML)}}, nil
+	}
+	a := NewCredentialedAPI(NewAPI("", nil, keyInfoFetcher("Character", 1<<9, fetcher)), APICredentials{KeyID: "1", VCode: "x"})
+	ids := make([]int64, listMaxIds+1)
+	ids[0], ids[1] = 297023723, 297023724
+	for i := 2; i < len(ids); i++ {
+		ids[i] = int64(i)
+	}
+	bodies, err := a.CharMailBodies(898901870, ids...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 batched calls, got %v", len(calls))
+	}
+	if n := len(strings.Split(calls[0].Get("ids"), ",")); n != listMaxIds {
+		t.Errorf("First batch had %v IDs", n)
+	}
+	if bodies.Bodies[297023723] != "Hi.<br><br>This is a message." {
+		t.Errorf("Wrong body returned. Got %+v", bodies)
+	}
+	if len(bodies.Missing) != 2 || bodies.Missing[0] != 297023724 {
+		t.Errorf("Wrong missing IDs returned. Got %+v", bodies.Missing)
+	}
+
+	calls = nil
+	bodies, err = a.CharMailBodies(898901870, 297023723)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 0 || bodies.Bodies[297023723] != "Hi.<br><br>This is a message." {
+		t.Errorf("Body was not served from cache. Calls: %v, bodies: %+v", calls, bodies)
+	}
+}
+
+const (
+	mailMessagesXML = `
+<result>
+    <rowset name="messages" key="messageID" columns="messageID,senderID,senderName,sentDate,title,toCorpOrAllianceID,toCharacterIDs,toListID">
+        <row messageID="290285276" senderID="999999999" senderName="Some Guy" sentDate="2009-12-01 01:04:00" title="Title 1" toCorpOrAllianceID="" toCharacterIDs="" toListID="128250439" />
+        <row messageID="290285275" senderID="999999998" senderName="Another Guy" sentDate="2009-12-01 01:04:00" title="Title 2" toCorpOrAllianceID="1234" toCharacterIDs="123,456" toListID="" />
+    </rowset>
+</result>
+`
+	mailingListsXML = `
+<result>
+    <rowset name="mailingLists" key="listID" columns="listID,displayName">
+        <row listID="128250439" displayName="EVETycoonMail" />
+        <row listID="128783669" displayName="EVEMarketScanner" />
+    </rowset>
+</result>
+`
+	mailBodiesXML = `
+<?xml version='1.0' encoding='UTF-8'?>
+<eveapi version="2">
+    <currentTime>2009-12-02 06:38:10</currentTime>
+    <result>
+        <rowset name="messages" key="messageID" columns="messageID">
+            <row messageID="297023723"><![CDATA[Hi.<br><br>This is a message.]]></row>
+        </rowset>
+        <missingMessageIDs>297023724</missingMessageIDs>
+    </result>
+    <cachedUntil>2019-12-02 06:38:10</cachedUntil>
+</eveapi>
+`
+)
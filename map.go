@@ -0,0 +1,221 @@
+package golink
+
+import (
+	"code.google.com/p/go-etree"
+	"fmt"
+	"net/url"
+)
+
+type mSystemJumps struct {
+	SolarSystemId, ShipJumps int64
+}
+
+type mSystemKills struct {
+	SolarSystemId, ShipKills, FactionKills, PodKills int64
+}
+
+type mSovereignty struct {
+	SolarSystemId                        int64
+	SolarSystemName                      string
+	AllianceId, FactionId, CorporationId int64
+}
+
+type mFacWarSystem struct {
+	SolarSystemId                           int64
+	SolarSystemName                         string
+	OccupyingFactionId, OwningFactionId     int64
+	OccupyingFactionName, OwningFactionName string
+	Contested                               bool
+	VictoryPoints, VictoryPointThreshold    int64
+}
+
+// Activity of one solar system, joined from map/Jumps, map/Kills,
+// map/Sovereignty and map/FacWarSystems. Sovereignty and FacWar are nil for
+// systems missing from those lists.
+type mSystemActivity struct {
+	SolarSystemId   int64
+	SolarSystemName string
+	ShipJumps       int64
+	Kills           mSystemKills
+	Sovereignty     *mSovereignty
+	FacWar          *mFacWarSystem
+}
+
+// Returns the number of jumps into each solar system in the last hour.
+// Systems without jumps are left out. This call needs no credentials.
+func (a *API) MapJumps() ([]mSystemJumps, error) {
+	rows, err := a.mapRows("map/Jumps")
+	if err != nil {
+		return nil, err
+	}
+	var r []mSystemJumps
+	for _, row := range rows {
+		j := mSystemJumps{}
+		if j.SolarSystemId, err = getIntAttr(row, "solarSystemID"); err != nil {
+			return nil, err
+		}
+		if j.ShipJumps, err = getIntAttr(row, "shipJumps"); err != nil {
+			return nil, err
+		}
+		r = append(r, j)
+	}
+	return r, nil
+}
+
+// Returns the kills in each solar system in the last hour. Systems without
+// kills are left out. This call needs no credentials.
+func (a *API) MapKills() ([]mSystemKills, error) {
+	rows, err := a.mapRows("map/Kills")
+	if err != nil {
+		return nil, err
+	}
+	var r []mSystemKills
+	for _, row := range rows {
+		k := mSystemKills{}
+		if k.SolarSystemId, err = getIntAttr(row, "solarSystemID"); err != nil {
+			return nil, err
+		}
+		if k.ShipKills, err = getIntAttr(row, "shipKills"); err != nil {
+			return nil, err
+		}
+		if k.FactionKills, err = getIntAttr(row, "factionKills"); err != nil {
+			return nil, err
+		}
+		if k.PodKills, err = getIntAttr(row, "podKills"); err != nil {
+			return nil, err
+		}
+		r = append(r, k)
+	}
+	return r, nil
+}
+
+// Returns the sovereignty of every solar system. This call needs no
+// credentials.
+func (a *API) MapSovereignty() ([]mSovereignty, error) {
+	rows, err := a.mapRows("map/Sovereignty")
+	if err != nil {
+		return nil, err
+	}
+	var r []mSovereignty
+	for _, row := range rows {
+		s := mSovereignty{}
+		if s.SolarSystemId, err = getIntAttr(row, "solarSystemID"); err != nil {
+			return nil, err
+		}
+		s.SolarSystemName = first(row.Get("solarSystemName"))
+		if s.AllianceId, err = getIntAttr(row, "allianceID"); err != nil {
+			return nil, err
+		}
+		if s.FactionId, err = getIntAttr(row, "factionID"); err != nil {
+			return nil, err
+		}
+		if s.CorporationId, err = getIntAttr(row, "corporationID"); err != nil {
+			return nil, err
+		}
+		r = append(r, s)
+	}
+	return r, nil
+}
+
+// Returns the solar systems contested in faction warfare. This call needs no
+// credentials.
+func (a *API) MapFacWarSystems() ([]mFacWarSystem, error) {
+	rows, err := a.mapRows("map/FacWarSystems")
+	if err != nil {
+		return nil, err
+	}
+	var r []mFacWarSystem
+	for _, row := range rows {
+		s := mFacWarSystem{}
+		if s.SolarSystemId, err = getIntAttr(row, "solarSystemID"); err != nil {
+			return nil, err
+		}
+		s.SolarSystemName = first(row.Get("solarSystemName"))
+		if s.OccupyingFactionId, err = getIntAttr(row, "occupyingFactionID"); err != nil {
+			return nil, err
+		}
+		if s.OwningFactionId, err = getIntAttr(row, "owningFactionID"); err != nil {
+			return nil, err
+		}
+		s.OccupyingFactionName = first(row.Get("occupyingFactionName"))
+		s.OwningFactionName = first(row.Get("owningFactionName"))
+		s.Contested = first(row.Get("contested")) == "True"
+		if s.VictoryPoints, err = getIntAttr(row, "victoryPoints"); err != nil {
+			return nil, err
+		}
+		if s.VictoryPointThreshold, err = getIntAttr(row, "victoryPointThreshold"); err != nil {
+			return nil, err
+		}
+		r = append(r, s)
+	}
+	return r, nil
+}
+
+// Fetches the four map calls and joins them with JoinSystemActivity.
+func (a *API) SystemActivity() (map[int64]mSystemActivity, error) {
+	jumps, err := a.MapJumps()
+	if err != nil {
+		return nil, err
+	}
+	kills, err := a.MapKills()
+	if err != nil {
+		return nil, err
+	}
+	sov, err := a.MapSovereignty()
+	if err != nil {
+		return nil, err
+	}
+	fw, err := a.MapFacWarSystems()
+	if err != nil {
+		return nil, err
+	}
+	return JoinSystemActivity(jumps, kills, sov, fw), nil
+}
+
+// Joins map call results by solar system. Every system in any of the lists
+// gets an entry.
+func JoinSystemActivity(jumps []mSystemJumps, kills []mSystemKills, sov []mSovereignty, fw []mFacWarSystem) map[int64]mSystemActivity {
+	r := make(map[int64]mSystemActivity)
+	get := func(id int64) mSystemActivity {
+		s, ok := r[id]
+		if !ok {
+			s.SolarSystemId = id
+		}
+		return s
+	}
+	for i := range sov {
+		s := get(sov[i].SolarSystemId)
+		s.SolarSystemName = sov[i].SolarSystemName
+		s.Sovereignty = &sov[i]
+		r[s.SolarSystemId] = s
+	}
+	for i := range fw {
+		s := get(fw[i].SolarSystemId)
+		s.SolarSystemName = fw[i].SolarSystemName
+		s.FacWar = &fw[i]
+		r[s.SolarSystemId] = s
+	}
+	for _, j := range jumps {
+		s := get(j.SolarSystemId)
+		s.ShipJumps = j.ShipJumps
+		r[s.SolarSystemId] = s
+	}
+	for _, k := range kills {
+		s := get(k.SolarSystemId)
+		s.Kills = k
+		r[s.SolarSystemId] = s
+	}
+	return r
+}
+
+func (a *API) mapRows(path string) ([]etree.Element, error) {
+	result, err := a.Get(path, url.Values{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	return rowset.FindAll("row"), nil
+}
@@ -0,0 +1,64 @@
+package golink
+
+import (
+	"testing"
+)
+
+func TestSystemActivity(t *testing.T) {
+	a := NewAPI("", nil, URLPathFetcher(map[string]string{
+		"map/Jumps":         mapJumpsXML,
+		"map/Kills":         mapKillsXML,
+		"map/Sovereignty":   mapSovereigntyXML,
+		"map/FacWarSystems": mapFacWarSystemsXML,
+	}))
+	activity, err := a.SystemActivity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(activity) != 4 {
+		t.Fatalf("Wrong number of systems. Got %+v", activity)
+	}
+	s := activity[30000142]
+	if s.SolarSystemName != "Jita" || s.ShipJumps != 2931 || s.Kills != (mSystemKills{SolarSystemId: 30000142, ShipKills: 5, FactionKills: 0, PodKills: 2}) {
+		t.Errorf("Wrong activity for Jita. Got %+v", s)
+	}
+	if s.Sovereignty == nil || s.Sovereignty.FactionId != 500001 || s.FacWar != nil {
+		t.Errorf("Wrong sovereignty for Jita. Got %+v", s)
+	}
+	s = activity[30002813]
+	if s.SolarSystemName != "Tama" || s.FacWar == nil || !s.FacWar.Contested || s.FacWar.VictoryPoints != 2890 || s.FacWar.OccupyingFactionName != "Gallente Federation" {
+		t.Errorf("Wrong activity for Tama. Got %+v", s)
+	}
+	if s = activity[30004711]; s.Sovereignty == nil || s.Sovereignty.AllianceId != 824518128 || s.ShipJumps != 0 {
+		t.Errorf("Wrong activity for a null-sec system. Got %+v", s)
+	}
+	if s = activity[30000144]; s.ShipJumps != 15 || s.Sovereignty != nil || s.SolarSystemName != "" {
+		t.Errorf("Wrong activity for a system only in map/Jumps. Got %+v", s)
+	}
+}
+
+const (
+	mapJumpsXML = `
+<rowset name="solarSystems" key="solarSystemID" columns="solarSystemID,shipJumps">
+    <row solarSystemID="30000142" shipJumps="2931" />
+    <row solarSystemID="30000144" shipJumps="15" />
+</rowset>
+`
+	mapKillsXML = `
+<rowset name="solarSystems" key="solarSystemID" columns="solarSystemID,shipKills,factionKills,podKills">
+    <row solarSystemID="30000142" shipKills="5" factionKills="0" podKills="2" />
+    <row solarSystemID="30002813" shipKills="12" factionKills="3" podKills="4" />
+</rowset>
+`
+	mapSovereigntyXML = `
+<rowset name="solarSystems" key="solarSystemID" columns="solarSystemID,allianceID,factionID,solarSystemName,corporationID">
+    <row solarSystemID="30000142" allianceID="0" factionID="500001" solarSystemName="Jita" corporationID="0" />
+    <row solarSystemID="30004711" allianceID="824518128" factionID="0" solarSystemName="H-PA29" corporationID="1019941433" />
+</rowset>
+`
+	mapFacWarSystemsXML = `
+<rowset name="solarSystems" key="solarSystemID" columns="solarSystemID,solarSystemName,occupyingFactionID,owningFactionID,occupyingFactionName,owningFactionName,contested,victoryPoints,victoryPointThreshold">
+    <row solarSystemID="30002813" solarSystemName="Tama" occupyingFactionID="500004" owningFactionID="500001" occupyingFactionName="Gallente Federation" owningFactionName="Caldari State" contested="True" victoryPoints="2890" victoryPointThreshold="75000" />
+</rowset>
+`
+)
@@ -0,0 +1,152 @@
+package golink
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type OrderState int64
+
+const (
+	OrderOpen OrderState = iota
+	OrderClosed
+	OrderExpired // Also used for fulfilled orders; check VolRemaining.
+	OrderCancelled
+	OrderPending
+	OrderCharacterDeleted
+)
+
+var orderStateNames = map[OrderState]string{
+	OrderOpen:             "open",
+	OrderClosed:           "closed",
+	OrderExpired:          "expired",
+	OrderCancelled:        "cancelled",
+	OrderPending:          "pending",
+	OrderCharacterDeleted: "character deleted",
+}
+
+func (s OrderState) String() string {
+	if n, ok := orderStateNames[s]; ok {
+		return n
+	}
+	return fmt.Sprintf("OrderState(%d)", int64(s))
+}
+
+// Order ranges other than a number of jumps.
+const (
+	RangeStation     = -1
+	RangeSolarSystem = 0
+	RangeRegion      = 32767
+)
+
+type cMarketOrder struct {
+	Id, CharId, StationId, TypeId       int64
+	VolEntered, VolRemaining, MinVolume int64
+	State                               OrderState
+	Range, AccountKey, Duration         int64
+	Escrow, Price                       float64
+	Bid                                 bool
+	Issued                              time.Time
+}
+
+type cMarketSummary struct {
+	Escrow, SellValue  float64
+	Expired, Fulfilled int
+}
+
+func (a *CredentialedAPI) CharMarketOrders(charId int64) ([]cMarketOrder, error) {
+	return a.marketOrders("char/MarketOrders", url.Values{"characterID": []string{formatId(charId)}})
+}
+
+func (a *CredentialedAPI) CorpMarketOrders() ([]cMarketOrder, error) {
+	return a.marketOrders("corp/MarketOrders", url.Values{})
+}
+
+func (a *CredentialedAPI) marketOrders(path string, params url.Values) ([]cMarketOrder, error) {
+	result, err := a.Get(path, params)
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cMarketOrder
+	for _, row := range rowset.FindAll("row") {
+		o := cMarketOrder{}
+		if o.Id, err = getIntAttr(row, "orderID"); err != nil {
+			return nil, err
+		}
+		if o.CharId, err = getIntAttr(row, "charID"); err != nil {
+			return nil, err
+		}
+		if o.StationId, err = getIntAttr(row, "stationID"); err != nil {
+			return nil, err
+		}
+		if o.TypeId, err = getIntAttr(row, "typeID"); err != nil {
+			return nil, err
+		}
+		if o.VolEntered, err = getIntAttr(row, "volEntered"); err != nil {
+			return nil, err
+		}
+		if o.VolRemaining, err = getIntAttr(row, "volRemaining"); err != nil {
+			return nil, err
+		}
+		if o.MinVolume, err = getIntAttr(row, "minVolume"); err != nil {
+			return nil, err
+		}
+		state, err := getIntAttr(row, "orderState")
+		if err != nil {
+			return nil, err
+		}
+		o.State = OrderState(state)
+		if o.Range, err = getIntAttr(row, "range"); err != nil {
+			return nil, err
+		}
+		if o.AccountKey, err = getIntAttr(row, "accountKey"); err != nil {
+			return nil, err
+		}
+		if o.Duration, err = getIntAttr(row, "duration"); err != nil {
+			return nil, err
+		}
+		if o.Escrow, err = getFloatAttr(row, "escrow"); err != nil {
+			return nil, err
+		}
+		if o.Price, err = getFloatAttr(row, "price"); err != nil {
+			return nil, err
+		}
+		o.Bid = first(row.Get("bid")) == "1"
+		if o.Issued, err = getTimeAttr(row, "issued"); err != nil {
+			return nil, err
+		}
+		r = append(r, o)
+	}
+	return r, nil
+}
+
+// Summarizes orders per station: escrow held by open buy orders, the value of
+// the remaining volume of open sell orders, and how many orders expired or
+// were fulfilled.
+func SummarizeMarketOrders(orders []cMarketOrder) map[int64]cMarketSummary {
+	r := make(map[int64]cMarketSummary)
+	for _, o := range orders {
+		s := r[o.StationId]
+		switch o.State {
+		case OrderOpen:
+			if o.Bid {
+				s.Escrow += o.Escrow
+			} else {
+				s.SellValue += o.Price * float64(o.VolRemaining)
+			}
+		case OrderExpired:
+			if o.VolRemaining == 0 {
+				s.Fulfilled++
+			} else {
+				s.Expired++
+			}
+		}
+		r[o.StationId] = s
+	}
+	return r
+}
@@ -0,0 +1,39 @@
+package golink
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMarketOrders(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(marketOrdersXML), APICredentials{})
+	orders, err := a.CharMarketOrders(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 4 {
+		t.Fatalf("Wrong number of orders. Got %+v", orders)
+	}
+	if orders[0] != (cMarketOrder{Id: 639356913, CharId: 118406849, StationId: 60008494, TypeId: 26082, VolEntered: 25, VolRemaining: 18, MinVolume: 1, State: OrderOpen, Range: RangeRegion, AccountKey: 1000, Duration: 3, Escrow: 0, Price: 3398000, Bid: false, Issued: time.Unix(1202046851, 0).UTC()}) {
+		t.Errorf("Wrong order returned. Got %+v", orders[0])
+	}
+	if !orders[1].Bid || orders[1].Range != RangeStation || orders[1].State.String() != "open" {
+		t.Errorf("Wrong order returned. Got %+v", orders[1])
+	}
+
+	summary := SummarizeMarketOrders(orders)
+	if summary[60008494] != (cMarketSummary{Escrow: 1500, SellValue: 18 * 3398000, Expired: 1, Fulfilled: 1}) {
+		t.Errorf("Wrong summary. Got %+v", summary)
+	}
+}
+
+const marketOrdersXML = `
+<result>
+    <rowset name="orders" key="orderID" columns="orderID,charID,stationID,volEntered,volRemaining,minVolume,orderState,typeID,range,accountKey,duration,escrow,price,bid,issued">
+        <row orderID="639356913" charID="118406849" stationID="60008494" volEntered="25" volRemaining="18" minVolume="1" orderState="0" typeID="26082" range="32767" accountKey="1000" duration="3" escrow="0.00" price="3398000.00" bid="0" issued="2008-02-03 13:54:11" />
+        <row orderID="639477821" charID="118406849" stationID="60008494" volEntered="25" volRemaining="24" minVolume="1" orderState="0" typeID="26082" range="-1" accountKey="1000" duration="3" escrow="1500.00" price="100.00" bid="1" issued="2008-02-03 13:54:11" />
+        <row orderID="639587440" charID="118406849" stationID="60008494" volEntered="25" volRemaining="4" minVolume="1" orderState="2" typeID="26082" range="32767" accountKey="1000" duration="1" escrow="0.00" price="3399999.98" bid="0" issued="2008-02-03 22:35:54" />
+        <row orderID="639587441" charID="118406849" stationID="60008494" volEntered="25" volRemaining="0" minVolume="1" orderState="2" typeID="26082" range="32767" accountKey="1000" duration="1" escrow="0.00" price="3399999.98" bid="0" issued="2008-02-03 22:35:54" />
+    </rowset>
+</result>
+`
@@ -0,0 +1,203 @@
+package golink
+
+import (
+	"code.google.com/p/go-etree"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type cMedal struct {
+	Id                 int64
+	Title, Description string
+	CreatorId          int64
+	Created            time.Time
+}
+
+// A medal awarded to a member. Status is "public" or "private".
+type cMemberMedal struct {
+	MedalId, CharacterId int64
+	Reason, Status       string
+	IssuerId             int64
+	Issued               time.Time
+}
+
+// A medal awarded to a character. CorporationId, Title and Description are
+// only set for medals from corporations other than the current one.
+type cCharMedal struct {
+	MedalId            int64
+	Reason, Status     string
+	IssuerId           int64
+	Issued             time.Time
+	CorporationId      int64
+	Title, Description string
+}
+
+type cCharMedals struct {
+	CurrentCorporation, OtherCorporations []cCharMedal
+}
+
+// A shareholder of the corporation. CorporationId and CorporationName are the
+// corporation of a character shareholder.
+type cShareholder struct {
+	Id              int64
+	Name            string
+	CorporationId   int64
+	CorporationName string
+	Shares          int64
+}
+
+type cShareholders struct {
+	Characters, Corporations []cShareholder
+}
+
+// Returns the medals the corporation has created.
+func (a *CredentialedAPI) CorpMedals() ([]cMedal, error) {
+	result, err := a.Get("corp/Medals", url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cMedal
+	for _, row := range rowset.FindAll("row") {
+		m := cMedal{}
+		if m.Id, err = getIntAttr(row, "medalID"); err != nil {
+			return nil, err
+		}
+		m.Title = first(row.Get("title"))
+		m.Description = first(row.Get("description"))
+		if m.CreatorId, err = getIntAttr(row, "creatorID"); err != nil {
+			return nil, err
+		}
+		if m.Created, err = getTimeAttr(row, "created"); err != nil {
+			return nil, err
+		}
+		r = append(r, m)
+	}
+	return r, nil
+}
+
+// Returns the medals awarded to the corporation's members.
+func (a *CredentialedAPI) CorpMemberMedals() ([]cMemberMedal, error) {
+	result, err := a.Get("corp/MemberMedals", url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cMemberMedal
+	for _, row := range rowset.FindAll("row") {
+		m := cMemberMedal{}
+		if m.MedalId, err = getIntAttr(row, "medalID"); err != nil {
+			return nil, err
+		}
+		if m.CharacterId, err = getIntAttr(row, "characterID"); err != nil {
+			return nil, err
+		}
+		m.Reason = first(row.Get("reason"))
+		m.Status = first(row.Get("status"))
+		if m.IssuerId, err = getIntAttr(row, "issuerID"); err != nil {
+			return nil, err
+		}
+		if m.Issued, err = getTimeAttr(row, "issued"); err != nil {
+			return nil, err
+		}
+		r = append(r, m)
+	}
+	return r, nil
+}
+
+// Returns the medals awarded to a character, by its current corporation and
+// by others.
+func (a *CredentialedAPI) CharMedals(charId int64) (cCharMedals, error) {
+	r := cCharMedals{}
+	result, err := a.Get("char/Medals", url.Values{"characterID": []string{formatId(charId)}})
+	if err != nil {
+		return r, err
+	}
+	if r.CurrentCorporation, err = parseCharMedals(findRowset(result, "currentCorporation")); err != nil {
+		return r, err
+	}
+	if r.OtherCorporations, err = parseCharMedals(findRowset(result, "otherCorporations")); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// Returns the character and corporation shareholders of the corporation.
+func (a *CredentialedAPI) CorpShareholders() (cShareholders, error) {
+	r := cShareholders{}
+	result, err := a.Get("corp/Shareholders", url.Values{})
+	if err != nil {
+		return r, err
+	}
+	if r.Characters, err = parseShareholders(findRowset(result, "characters")); err != nil {
+		return r, err
+	}
+	if r.Corporations, err = parseShareholders(findRowset(result, "corporations")); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+func parseCharMedals(rowset etree.Element) ([]cCharMedal, error) {
+	var r []cCharMedal
+	if rowset == nil {
+		return r, nil
+	}
+	var err error
+	for _, row := range rowset.FindAll("row") {
+		m := cCharMedal{}
+		if m.MedalId, err = getIntAttr(row, "medalID"); err != nil {
+			return nil, err
+		}
+		m.Reason = first(row.Get("reason"))
+		m.Status = first(row.Get("status"))
+		if m.IssuerId, err = getIntAttr(row, "issuerID"); err != nil {
+			return nil, err
+		}
+		if m.Issued, err = getTimeAttr(row, "issued"); err != nil {
+			return nil, err
+		}
+		if temp := first(row.Get("corporationID")); temp != "" {
+			if m.CorporationId, err = getIntAttr(row, "corporationID"); err != nil {
+				return nil, err
+			}
+		}
+		m.Title = first(row.Get("title"))
+		m.Description = first(row.Get("description"))
+		r = append(r, m)
+	}
+	return r, nil
+}
+
+func parseShareholders(rowset etree.Element) ([]cShareholder, error) {
+	var r []cShareholder
+	if rowset == nil {
+		return r, nil
+	}
+	var err error
+	for _, row := range rowset.FindAll("row") {
+		s := cShareholder{}
+		if s.Id, err = getIntAttr(row, "shareholderID"); err != nil {
+			return nil, err
+		}
+		s.Name = first(row.Get("shareholderName"))
+		if temp := first(row.Get("shareholderCorporationID")); temp != "" {
+			if s.CorporationId, err = getIntAttr(row, "shareholderCorporationID"); err != nil {
+				return nil, err
+			}
+		}
+		s.CorporationName = first(row.Get("shareholderCorporationName"))
+		if s.Shares, err = getIntAttr(row, "shares"); err != nil {
+			return nil, err
+		}
+		r = append(r, s)
+	}
+	return r, nil
+}
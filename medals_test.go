@@ -0,0 +1,98 @@
+package golink
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCorpMedals(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(corpMedalsXML), APICredentials{})
+	medals, err := a.CorpMedals()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := cMedal{Id: 1001, Title: "Best Miner", Description: "For mining the most ore.", CreatorId: 150208955, Created: time.Date(2010, 3, 1, 12, 0, 0, 0, time.UTC)}
+	if len(medals) != 1 || medals[0] != expected {
+		t.Errorf("Wrong medals. Got %+v", medals)
+	}
+}
+
+func TestCorpMemberMedals(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(memberMedalsXML), APICredentials{})
+	medals, err := a.CorpMemberMedals()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := cMemberMedal{MedalId: 1001, CharacterId: 150189636, Reason: "Mined a lot.", Status: "public", IssuerId: 150208955, Issued: time.Date(2010, 3, 2, 10, 30, 0, 0, time.UTC)}
+	if len(medals) != 2 || medals[0] != expected || medals[1].Status != "private" {
+		t.Errorf("Wrong member medals. Got %+v", medals)
+	}
+}
+
+func TestCharMedals(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(charMedalsXML), APICredentials{})
+	medals, err := a.CharMedals(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(medals.CurrentCorporation) != 1 || medals.CurrentCorporation[0] != (cCharMedal{MedalId: 1001, Reason: "Mined a lot.", Status: "public", IssuerId: 150208955, Issued: time.Date(2010, 3, 2, 10, 30, 0, 0, time.UTC)}) {
+		t.Errorf("Wrong current corporation medals. Got %+v", medals.CurrentCorporation)
+	}
+	expected := cCharMedal{MedalId: 77, Reason: "Held the line.", Status: "private", IssuerId: 123, Issued: time.Date(2008, 1, 5, 20, 0, 0, 0, time.UTC),
+		CorporationId: 98000001, Title: "Defender", Description: "Defended the home system."}
+	if len(medals.OtherCorporations) != 1 || medals.OtherCorporations[0] != expected {
+		t.Errorf("Wrong other corporation medals. Got %+v", medals.OtherCorporations)
+	}
+}
+
+func TestCorpShareholders(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(shareholdersXML), APICredentials{})
+	holders, err := a.CorpShareholders()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(holders.Characters) != 1 || holders.Characters[0] != (cShareholder{Id: 126891489, Name: "Dragonaire", CorporationId: 632257314, CorporationName: "Corax.", Shares: 1}) {
+		t.Errorf("Wrong character shareholders. Got %+v", holders.Characters)
+	}
+	if len(holders.Corporations) != 1 || holders.Corporations[0] != (cShareholder{Id: 126891482, Name: "DragonaireCorp", Shares: 1}) {
+		t.Errorf("Wrong corporation shareholders. Got %+v", holders.Corporations)
+	}
+}
+
+const (
+	corpMedalsXML = `
+<result>
+    <rowset name="medals" key="medalID" columns="medalID,title,description,creatorID,created">
+        <row medalID="1001" title="Best Miner" description="For mining the most ore." creatorID="150208955" created="2010-03-01 12:00:00" />
+    </rowset>
+</result>
+`
+	memberMedalsXML = `
+<result>
+    <rowset name="issuedMedals" key="medalID,characterID" columns="medalID,characterID,reason,status,issuerID,issued">
+        <row medalID="1001" characterID="150189636" reason="Mined a lot." status="public" issuerID="150208955" issued="2010-03-02 10:30:00" />
+        <row medalID="1001" characterID="150208955" reason="Also mined." status="private" issuerID="150208955" issued="2010-03-02 10:31:00" />
+    </rowset>
+</result>
+`
+	charMedalsXML = `
+<result>
+    <rowset name="currentCorporation" key="medalID" columns="medalID,reason,status,issuerID,issued">
+        <row medalID="1001" reason="Mined a lot." status="public" issuerID="150208955" issued="2010-03-02 10:30:00" />
+    </rowset>
+    <rowset name="otherCorporations" key="medalID" columns="medalID,reason,status,issuerID,issued,corporationID,title,description">
+        <row medalID="77" reason="Held the line." status="private" issuerID="123" issued="2008-01-05 20:00:00" corporationID="98000001" title="Defender" description="Defended the home system." />
+    </rowset>
+</result>
+`
+	shareholdersXML = `
+<result>
+    <rowset name="characters" key="shareholderID" columns="shareholderID,shareholderName,shareholderCorporationID,shareholderCorporationName,shares">
+        <row shareholderID="126891489" shareholderName="Dragonaire" shareholderCorporationID="632257314" shareholderCorporationName="Corax." shares="1" />
+    </rowset>
+    <rowset name="corporations" key="shareholderID" columns="shareholderID,shareholderName,shares">
+        <row shareholderID="126891482" shareholderName="DragonaireCorp" shares="1" />
+    </rowset>
+</result>
+`
+)
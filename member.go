@@ -0,0 +1,255 @@
+package golink
+
+import (
+	"code.google.com/p/go-etree"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type cMemberTracking struct {
+	CharacterId int64
+	Name, Title string
+	Start       time.Time
+	BaseId      int64
+	Base        string
+	// The remaining fields are only filled in by extended tracking.
+	Logon, Logoff         time.Time
+	LocationId            int64
+	Location              string
+	ShipTypeId            int64
+	ShipType              string
+	Roles, GrantableRoles Role
+}
+
+type cMemberSecurity struct {
+	CharacterId                         int64
+	Name                                string
+	Roles, GrantableRoles               Role
+	RolesAtHQ, GrantableRolesAtHQ       Role
+	RolesAtBase, GrantableRolesAtBase   Role
+	RolesAtOther, GrantableRolesAtOther Role
+	Titles                              map[int64]string
+}
+
+type cTitle struct {
+	Id                                  int64
+	Name                                string
+	Roles, GrantableRoles               Role
+	RolesAtHQ, GrantableRolesAtHQ       Role
+	RolesAtBase, GrantableRolesAtBase   Role
+	RolesAtOther, GrantableRolesAtOther Role
+}
+
+type cMemberSecurityChange struct {
+	Time               time.Time
+	CharacterId        int64
+	CharacterName      string
+	IssuerId           int64
+	IssuerName         string
+	RoleLocationType   string
+	OldRoles, NewRoles Role
+}
+
+// Returns the corporation's members. Extended tracking additionally reports
+// logon times, location, ship and roles.
+func (a *CredentialedAPI) CorpMemberTracking(extended bool) ([]cMemberTracking, error) {
+	params := url.Values{}
+	if extended {
+		params["extended"] = []string{"1"}
+	}
+	result, err := a.Get("corp/MemberTracking", params)
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cMemberTracking
+	for _, row := range rowset.FindAll("row") {
+		m := cMemberTracking{}
+		if m.CharacterId, err = getIntAttr(row, "characterID"); err != nil {
+			return nil, err
+		}
+		m.Name = first(row.Get("name"))
+		m.Title = first(row.Get("title"))
+		if m.Start, err = getTimeAttr(row, "startDateTime"); err != nil {
+			return nil, err
+		}
+		if m.BaseId, err = getIntAttr(row, "baseID"); err != nil {
+			return nil, err
+		}
+		m.Base = first(row.Get("base"))
+		if !extended {
+			r = append(r, m)
+			continue
+		}
+		if m.Logon, err = getOptTimeAttr(row, "logonDateTime"); err != nil {
+			return nil, err
+		}
+		if m.Logoff, err = getOptTimeAttr(row, "logoffDateTime"); err != nil {
+			return nil, err
+		}
+		if m.LocationId, err = getIntAttr(row, "locationID"); err != nil {
+			return nil, err
+		}
+		m.Location = first(row.Get("location"))
+		if m.ShipTypeId, err = getIntAttr(row, "shipTypeID"); err != nil {
+			return nil, err
+		}
+		m.ShipType = first(row.Get("shipType"))
+		roles, err := getIntAttr(row, "roles")
+		if err != nil {
+			return nil, err
+		}
+		m.Roles = Role(roles)
+		if roles, err = getIntAttr(row, "grantableRoles"); err != nil {
+			return nil, err
+		}
+		m.GrantableRoles = Role(roles)
+		r = append(r, m)
+	}
+	return r, nil
+}
+
+func (a *CredentialedAPI) CorpMemberSecurity() ([]cMemberSecurity, error) {
+	result, err := a.Get("corp/MemberSecurity", url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cMemberSecurity
+	for _, row := range rowset.FindAll("row") {
+		m := cMemberSecurity{Titles: make(map[int64]string)}
+		if m.CharacterId, err = getIntAttr(row, "characterID"); err != nil {
+			return nil, err
+		}
+		m.Name = first(row.Get("name"))
+		for name, mask := range map[string]*Role{
+			"roles":                 &m.Roles,
+			"grantableRoles":        &m.GrantableRoles,
+			"rolesAtHQ":             &m.RolesAtHQ,
+			"grantableRolesAtHQ":    &m.GrantableRolesAtHQ,
+			"rolesAtBase":           &m.RolesAtBase,
+			"grantableRolesAtBase":  &m.GrantableRolesAtBase,
+			"rolesAtOther":          &m.RolesAtOther,
+			"grantableRolesAtOther": &m.GrantableRolesAtOther,
+		} {
+			if *mask, err = parseRoleRowset(findRowset(row, name)); err != nil {
+				return nil, err
+			}
+		}
+		if titles := findRowset(row, "titles"); titles != nil {
+			for _, trow := range titles.FindAll("row") {
+				id, err := getIntAttr(trow, "titleID")
+				if err != nil {
+					return nil, err
+				}
+				m.Titles[id] = first(trow.Get("titleName"))
+			}
+		}
+		r = append(r, m)
+	}
+	return r, nil
+}
+
+// Returns the corporation's titles and the roles each grants.
+func (a *CredentialedAPI) CorpTitles() ([]cTitle, error) {
+	result, err := a.Get("corp/Titles", url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	rowset := findRowset(result, "titles")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cTitle
+	for _, row := range rowset.FindAll("row") {
+		t := cTitle{}
+		if t.Id, err = getIntAttr(row, "titleID"); err != nil {
+			return nil, err
+		}
+		t.Name = first(row.Get("titleName"))
+		for name, mask := range map[string]*Role{
+			"roles":                 &t.Roles,
+			"grantableRoles":        &t.GrantableRoles,
+			"rolesAtHQ":             &t.RolesAtHQ,
+			"grantableRolesAtHQ":    &t.GrantableRolesAtHQ,
+			"rolesAtBase":           &t.RolesAtBase,
+			"grantableRolesAtBase":  &t.GrantableRolesAtBase,
+			"rolesAtOther":          &t.RolesAtOther,
+			"grantableRolesAtOther": &t.GrantableRolesAtOther,
+		} {
+			if *mask, err = parseRoleRowset(findRowset(row, name)); err != nil {
+				return nil, err
+			}
+		}
+		r = append(r, t)
+	}
+	return r, nil
+}
+
+func (a *CredentialedAPI) CorpMemberSecurityLog() ([]cMemberSecurityChange, error) {
+	result, err := a.Get("corp/MemberSecurityLog", url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cMemberSecurityChange
+	for _, row := range rowset.FindAll("row") {
+		c := cMemberSecurityChange{}
+		if c.Time, err = getTimeAttr(row, "changeTime"); err != nil {
+			return nil, err
+		}
+		if c.CharacterId, err = getIntAttr(row, "characterID"); err != nil {
+			return nil, err
+		}
+		c.CharacterName = first(row.Get("characterName"))
+		if c.IssuerId, err = getIntAttr(row, "issuerID"); err != nil {
+			return nil, err
+		}
+		c.IssuerName = first(row.Get("issuerName"))
+		c.RoleLocationType = first(row.Get("roleLocationType"))
+		if c.OldRoles, err = parseRoleRowset(findRowset(row, "oldRoles")); err != nil {
+			return nil, err
+		}
+		if c.NewRoles, err = parseRoleRowset(findRowset(row, "newRoles")); err != nil {
+			return nil, err
+		}
+		r = append(r, c)
+	}
+	return r, nil
+}
+
+// Combines a rowset of roleID/roleName rows into a role mask. Each roleID is
+// the bit of that role, so a missing rowset yields no roles. Rows without a
+// roleID are looked up by roleName.
+func parseRoleRowset(rowset etree.Element) (Role, error) {
+	var mask Role
+	if rowset == nil {
+		return 0, nil
+	}
+	for _, row := range rowset.FindAll("row") {
+		if _, ok := row.Get("roleID"); !ok {
+			role, err := ParseRoleName(first(row.Get("roleName")))
+			if err != nil {
+				return 0, err
+			}
+			mask = mask.Add(role)
+			continue
+		}
+		id, err := getIntAttr(row, "roleID")
+		if err != nil {
+			return 0, err
+		}
+		mask = mask.Add(Role(id))
+	}
+	return mask, nil
+}
@@ -0,0 +1,163 @@
+package golink
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemberTracking(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(memberTrackingXML), APICredentials{})
+	members, err := a.CorpMemberTracking(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 1 {
+		t.Fatalf("Wrong number of members. Got %+v", members)
+	}
+	expected := cMemberTracking{CharacterId: 150336922, Name: "corpexport", Title: "asdf", Start: time.Unix(1181745540, 0).UTC(),
+		Logon: time.Unix(1182029760, 0).UTC(), Logoff: time.Unix(1182030420, 0).UTC(), LocationId: 60011566,
+		Location: "Bourynes VII - Moon 2 - University of Caille School", ShipTypeId: -1, Roles: 4096, GrantableRoles: 0}
+	if members[0] != expected {
+		t.Errorf("Wrong member returned. Got %+v", members[0])
+	}
+	members, err = a.CorpMemberTracking(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if members[0].LocationId != 0 || !members[0].Logon.IsZero() {
+		t.Errorf("Basic tracking returned extended fields. Got %+v", members[0])
+	}
+}
+
+func TestMemberSecurity(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(memberSecurityXML), APICredentials{})
+	members, err := a.CorpMemberSecurity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 1 {
+		t.Fatalf("Wrong number of members. Got %+v", members)
+	}
+	m := members[0]
+	if m.CharacterId != 123456789 || m.Roles != 1|128 || m.GrantableRoles != 0 || m.RolesAtHQ != 8192 || m.GrantableRolesAtOther != 8192 {
+		t.Errorf("Wrong roles returned. Got %+v", m)
+	}
+	if len(m.Titles) != 2 || m.Titles[512] != "Gas Attendant" {
+		t.Errorf("Wrong titles returned. Got %+v", m.Titles)
+	}
+}
+
+func TestTitles(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(titlesXML), APICredentials{})
+	titles, err := a.CorpTitles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(titles) != 2 {
+		t.Fatalf("Wrong number of titles. Got %+v", titles)
+	}
+	expected := cTitle{Id: 2, Name: "Finance", Roles: RoleAccountant | RoleJuniorAccountant, GrantableRoles: RoleJuniorAccountant, RolesAtHQ: RoleFactoryManager}
+	if titles[1] != expected {
+		t.Errorf("Wrong title. Got %+v", titles[1])
+	}
+	if titles[0] != (cTitle{Id: 1, Name: "Member"}) {
+		t.Errorf("Wrong title. Got %+v", titles[0])
+	}
+}
+
+func TestMemberSecurityLog(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(memberSecurityLogXML), APICredentials{})
+	log, err := a.CorpMemberSecurityLog()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(log) != 1 {
+		t.Fatalf("Wrong number of changes. Got %+v", log)
+	}
+	if log[0] != (cMemberSecurityChange{Time: time.Unix(1222948800, 0).UTC(), CharacterId: 1234567890, CharacterName: "Tester", IssuerId: 1234567891, IssuerName: "Director", RoleLocationType: "rolesAtOther", OldRoles: 0, NewRoles: 8192 | 16384}) {
+		t.Errorf("Wrong change returned. Got %+v", log[0])
+	}
+}
+
+const (
+	titlesXML = `
+<result>
+    <rowset name="titles" key="titleID" columns="titleID,titleName">
+        <row titleID="1" titleName="Member">
+            <rowset name="roles" key="roleID" columns="roleID,roleName,roleDescription" />
+            <rowset name="grantableRoles" key="roleID" columns="roleID,roleName,roleDescription" />
+            <rowset name="rolesAtHQ" key="roleID" columns="roleID,roleName,roleDescription" />
+            <rowset name="grantableRolesAtHQ" key="roleID" columns="roleID,roleName,roleDescription" />
+            <rowset name="rolesAtBase" key="roleID" columns="roleID,roleName,roleDescription" />
+            <rowset name="grantableRolesAtBase" key="roleID" columns="roleID,roleName,roleDescription" />
+            <rowset name="rolesAtOther" key="roleID" columns="roleID,roleName,roleDescription" />
+            <rowset name="grantableRolesAtOther" key="roleID" columns="roleID,roleName,roleDescription" />
+        </row>
+        <row titleID="2" titleName="Finance">
+            <rowset name="roles" key="roleID" columns="roleID,roleName,roleDescription">
+                <row roleID="256" roleName="roleAccountant" roleDescription="Can view and use corporation accounts." />
+                <row roleID="4503599627370496" roleName="roleJuniorAccountant" roleDescription="Can view corporation accounts." />
+            </rowset>
+            <rowset name="grantableRoles" key="roleID" columns="roleID,roleName,roleDescription">
+                <row roleID="4503599627370496" roleName="roleJuniorAccountant" roleDescription="Can view corporation accounts." />
+            </rowset>
+            <rowset name="rolesAtHQ" key="roleID" columns="roleID,roleName,roleDescription">
+                <row roleID="1024" roleName="roleFactoryManager" roleDescription="Can manage factories." />
+            </rowset>
+            <rowset name="grantableRolesAtHQ" key="roleID" columns="roleID,roleName,roleDescription" />
+            <rowset name="rolesAtBase" key="roleID" columns="roleID,roleName,roleDescription" />
+            <rowset name="grantableRolesAtBase" key="roleID" columns="roleID,roleName,roleDescription" />
+            <rowset name="rolesAtOther" key="roleID" columns="roleID,roleName,roleDescription" />
+            <rowset name="grantableRolesAtOther" key="roleID" columns="roleID,roleName,roleDescription" />
+        </row>
+    </rowset>
+</result>
+`
+	memberTrackingXML = `
+<result>
+    <rowset name="members" key="characterID" columns="characterID,name,startDateTime,baseID,base,title,logonDateTime,logoffDateTime,locationID,location,shipTypeID,shipType,roles,grantableRoles">
+        <row characterID="150336922" name="corpexport" startDateTime="2007-06-13 14:39:00" baseID="0" base="" title="asdf" logonDateTime="2007-06-16 21:36:00" logoffDateTime="2007-06-16 21:47:00" locationID="60011566" location="Bourynes VII - Moon 2 - University of Caille School" shipTypeID="-1" shipType="" roles="4096" grantableRoles="0" />
+    </rowset>
+</result>
+`
+	memberSecurityXML = `
+<result>
+    <rowset name="members" key="characterID" columns="characterID,name">
+        <row characterID="123456789" name="Tester">
+            <rowset name="roles" key="roleID" columns="roleID,roleName">
+                <row roleID="1" roleName="roleDirector" />
+                <row roleID="128" roleName="rolePersonnelManager" />
+            </rowset>
+            <rowset name="grantableRoles" key="roleID" columns="roleID,roleName" />
+            <rowset name="rolesAtHQ" key="roleID" columns="roleID,roleName">
+                <row roleID="8192" roleName="roleHangarCanTake1" />
+            </rowset>
+            <rowset name="grantableRolesAtHQ" key="roleID" columns="roleID,roleName" />
+            <rowset name="rolesAtBase" key="roleID" columns="roleID,roleName" />
+            <rowset name="grantableRolesAtBase" key="roleID" columns="roleID,roleName" />
+            <rowset name="rolesAtOther" key="roleID" columns="roleID,roleName" />
+            <rowset name="grantableRolesAtOther" key="roleID" columns="roleID,roleName">
+                <row roleID="8192" roleName="roleHangarCanTake1" />
+            </rowset>
+            <rowset name="titles" key="titleID" columns="titleID,titleName">
+                <row titleID="1" titleName="Member" />
+                <row titleID="512" titleName="Gas Attendant" />
+            </rowset>
+        </row>
+    </rowset>
+</result>
+`
+	memberSecurityLogXML = `
+<result>
+    <rowset name="roleHistory" key="changeTime" columns="changeTime,characterID,characterName,issuerID,issuerName,roleLocationType">
+        <row changeTime="2008-10-02 12:00:00" characterID="1234567890" characterName="Tester" issuerID="1234567891" issuerName="Director" roleLocationType="rolesAtOther">
+            <rowset name="oldRoles" key="roleID" columns="roleID,roleName" />
+            <rowset name="newRoles" key="roleID" columns="roleID,roleName">
+                <row roleID="8192" roleName="roleHangarCanTake1" />
+                <row roleID="16384" roleName="roleHangarCanTake2" />
+            </rowset>
+        </row>
+    </rowset>
+</result>
+`
+)
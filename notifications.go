@@ -0,0 +1,371 @@
+package golink
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type cNotification struct {
+	Id, TypeId, SenderId int64
+	SenderName           string
+	Sent                 time.Time
+	Read                 bool
+}
+
+type cNotificationTexts struct {
+	Texts   map[int64]string
+	Missing []int64
+}
+
+// Payload of a war declaration (alliance or corporation).
+type WarDeclaration struct {
+	AgainstId, DeclaredById int64
+	Cost                    float64
+	DelayHours              int64
+	HostileState            bool
+}
+
+// Payload of a control tower or sovereignty structure under attack. MoonId is
+// only set for control towers.
+type StructureAttack struct {
+	AggressorId, AggressorCorpId, AggressorAllianceId int64
+	SolarSystemId, MoonId, TypeId                     int64
+	ShieldValue, ArmorValue, HullValue                float64
+}
+
+// Payload of a control tower running low on fuel.
+type TowerResourceAlert struct {
+	AllianceId, CorpId, SolarSystemId, MoonId, TypeId int64
+	Wants                                             []TowerResource
+}
+
+type TowerResource struct {
+	TypeId, Quantity int64
+}
+
+// Payload of a customs office entering reinforced mode.
+type OrbitalReinforced struct {
+	AggressorId, AggressorCorpId, AggressorAllianceId int64
+	SolarSystemId, PlanetId, TypeId                   int64
+	ReinforceExitTime                                 time.Time
+}
+
+// Payload of a sovereignty claim being acquired or lost.
+type SovereigntyChange struct {
+	AllianceId, CorpId, SolarSystemId int64
+}
+
+// Payload of a kill report, either as victim or for the final blow.
+type KillReport struct {
+	KillMailId       int64
+	KillMailHash     string
+	VictimShipTypeId int64
+}
+
+type notificationDecoder func(map[string]interface{}) (interface{}, error)
+
+// Maps notification typeIDs to decoders for their bodies. Types not listed here
+// are decoded into a plain map by DecodeNotification.
+var notificationTypes = map[int64]notificationDecoder{
+	5:   decodeWarDeclaration,     // Alliance war declared
+	27:  decodeWarDeclaration,     // Corporation war declared
+	41:  decodeSovereigntyChange,  // Sovereignty claim lost
+	43:  decodeSovereigntyChange,  // Sovereignty claim acquired
+	75:  decodeStructureAttack,    // Tower alert
+	76:  decodeTowerResourceAlert, // Tower resource alert
+	86:  decodeStructureAttack,    // Territorial Claim Unit under attack
+	87:  decodeStructureAttack,    // Sovereignty Blockade Unit under attack
+	88:  decodeStructureAttack,    // Infrastructure Hub under attack
+	94:  decodeOrbitalReinforced,  // Customs office reinforced
+	184: decodeKillReport,         // Kill report, final blow
+	185: decodeKillReport,         // Kill report, victim
+}
+
+func (a *CredentialedAPI) CharNotifications(charId int64) ([]cNotification, error) {
+	result, err := a.Get("char/Notifications", url.Values{"characterID": []string{formatId(charId)}})
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cNotification
+	for _, row := range rowset.FindAll("row") {
+		n := cNotification{}
+		if n.Id, err = getIntAttr(row, "notificationID"); err != nil {
+			return nil, err
+		}
+		if n.TypeId, err = getIntAttr(row, "typeID"); err != nil {
+			return nil, err
+		}
+		if n.SenderId, err = getIntAttr(row, "senderID"); err != nil {
+			return nil, err
+		}
+		n.SenderName = first(row.Get("senderName"))
+		if n.Sent, err = getTimeAttr(row, "sentDate"); err != nil {
+			return nil, err
+		}
+		n.Read = first(row.Get("read")) == "1"
+		r = append(r, n)
+	}
+	return r, nil
+}
+
+// Fetches the raw bodies of the given notifications, splitting the IDs into
+// batches the API will accept. IDs the server does not know about are reported
+// in Missing.
+func (a *CredentialedAPI) CharNotificationTexts(charId int64, ids ...int64) (cNotificationTexts, error) {
+	r := cNotificationTexts{Texts: make(map[int64]string)}
+	for _, batch := range batchIds(ids) {
+		result, err := a.Get("char/NotificationTexts", url.Values{"characterID": []string{formatId(charId)}, "IDs": []string{joinIds(batch)}})
+		if err != nil {
+			return r, err
+		}
+		if rowset := result.Find("rowset"); rowset != nil {
+			for _, row := range rowset.FindAll("row") {
+				id, err := getIntAttr(row, "notificationID")
+				if err != nil {
+					return r, err
+				}
+				r.Texts[id] = row.Text()
+			}
+		}
+		if missing := result.Find("missingIDs"); missing != nil {
+			m, err := parseIdList(missing.Text())
+			if err != nil {
+				return r, err
+			}
+			r.Missing = append(r.Missing, m...)
+		}
+	}
+	return r, nil
+}
+
+// Decodes a notification body into the payload struct registered for its
+// typeID, or into a map[string]interface{} for types without one.
+func DecodeNotification(typeId int64, text string) (interface{}, error) {
+	v, err := parseYAML(text)
+	if err != nil {
+		return nil, err
+	}
+	kv, ok := v.(map[string]interface{})
+	if v == nil {
+		kv = make(map[string]interface{})
+	} else if !ok {
+		return nil, fmt.Errorf("Notification body is not a map: %v", text)
+	}
+	if decode, ok := notificationTypes[typeId]; ok {
+		return decode(kv)
+	}
+	return kv, nil
+}
+
+func kvInt(kv map[string]interface{}, k string) (int64, error) {
+	switch v := kv[k].(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		return v, nil
+	case float64:
+		if v == float64(int64(v)) {
+			return int64(v), nil
+		}
+	}
+	return 0, fmt.Errorf("Notification field %v is not an integer: %v", k, kv[k])
+}
+
+func kvFloat(kv map[string]interface{}, k string) (float64, error) {
+	switch v := kv[k].(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	}
+	return 0, fmt.Errorf("Notification field %v is not a number: %v", k, kv[k])
+}
+
+func kvBool(kv map[string]interface{}, k string) (bool, error) {
+	switch v := kv[k].(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	case int64:
+		if v == 0 || v == 1 {
+			return v == 1, nil
+		}
+	}
+	return false, fmt.Errorf("Notification field %v is not a boolean: %v", k, kv[k])
+}
+
+func kvString(kv map[string]interface{}, k string) string {
+	if kv[k] == nil {
+		return ""
+	}
+	return fmt.Sprint(kv[k])
+}
+
+// Decodes a field holding a Windows FILETIME, as used for timestamps in
+// notification bodies.
+func kvTime(kv map[string]interface{}, k string) (time.Time, error) {
+	if kv[k] == nil {
+		return time.Time{}, nil
+	}
+	i, err := kvInt(kv, k)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parseMSDate(strconv.FormatInt(i, 10))
+}
+
+func decodeWarDeclaration(kv map[string]interface{}) (interface{}, error) {
+	r := WarDeclaration{}
+	var err error
+	if r.AgainstId, err = kvInt(kv, "againstID"); err != nil {
+		return nil, err
+	}
+	if r.DeclaredById, err = kvInt(kv, "declaredByID"); err != nil {
+		return nil, err
+	}
+	if r.Cost, err = kvFloat(kv, "cost"); err != nil {
+		return nil, err
+	}
+	if r.DelayHours, err = kvInt(kv, "delayHours"); err != nil {
+		return nil, err
+	}
+	if r.HostileState, err = kvBool(kv, "hostileState"); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func decodeStructureAttack(kv map[string]interface{}) (interface{}, error) {
+	r := StructureAttack{}
+	var err error
+	if r.AggressorId, err = kvInt(kv, "aggressorID"); err != nil {
+		return nil, err
+	}
+	if r.AggressorCorpId, err = kvInt(kv, "aggressorCorpID"); err != nil {
+		return nil, err
+	}
+	if r.AggressorAllianceId, err = kvInt(kv, "aggressorAllianceID"); err != nil {
+		return nil, err
+	}
+	if r.SolarSystemId, err = kvInt(kv, "solarSystemID"); err != nil {
+		return nil, err
+	}
+	if r.MoonId, err = kvInt(kv, "moonID"); err != nil {
+		return nil, err
+	}
+	if r.TypeId, err = kvInt(kv, "typeID"); err != nil {
+		return nil, err
+	}
+	if r.ShieldValue, err = kvFloat(kv, "shieldValue"); err != nil {
+		return nil, err
+	}
+	if r.ArmorValue, err = kvFloat(kv, "armorValue"); err != nil {
+		return nil, err
+	}
+	if r.HullValue, err = kvFloat(kv, "hullValue"); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func decodeSovereigntyChange(kv map[string]interface{}) (interface{}, error) {
+	r := SovereigntyChange{}
+	var err error
+	if r.AllianceId, err = kvInt(kv, "allianceID"); err != nil {
+		return nil, err
+	}
+	if r.CorpId, err = kvInt(kv, "corpID"); err != nil {
+		return nil, err
+	}
+	if r.SolarSystemId, err = kvInt(kv, "solarSystemID"); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func decodeKillReport(kv map[string]interface{}) (interface{}, error) {
+	r := KillReport{KillMailHash: kvString(kv, "killMailHash")}
+	var err error
+	if r.KillMailId, err = kvInt(kv, "killMailID"); err != nil {
+		return nil, err
+	}
+	if r.VictimShipTypeId, err = kvInt(kv, "victimShipTypeID"); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func decodeTowerResourceAlert(kv map[string]interface{}) (interface{}, error) {
+	r := TowerResourceAlert{}
+	var err error
+	if r.AllianceId, err = kvInt(kv, "allianceID"); err != nil {
+		return nil, err
+	}
+	if r.CorpId, err = kvInt(kv, "corpID"); err != nil {
+		return nil, err
+	}
+	if r.SolarSystemId, err = kvInt(kv, "solarSystemID"); err != nil {
+		return nil, err
+	}
+	if r.MoonId, err = kvInt(kv, "moonID"); err != nil {
+		return nil, err
+	}
+	if r.TypeId, err = kvInt(kv, "typeID"); err != nil {
+		return nil, err
+	}
+	wants, ok := kv["wants"].([]interface{})
+	if !ok && kv["wants"] != nil {
+		return nil, fmt.Errorf("Notification field wants is not a list: %v", kv["wants"])
+	}
+	for _, w := range wants {
+		wkv, ok := w.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Notification field wants has a non-map entry: %v", w)
+		}
+		res := TowerResource{}
+		if res.TypeId, err = kvInt(wkv, "typeID"); err != nil {
+			return nil, err
+		}
+		if res.Quantity, err = kvInt(wkv, "quantity"); err != nil {
+			return nil, err
+		}
+		r.Wants = append(r.Wants, res)
+	}
+	return r, nil
+}
+
+func decodeOrbitalReinforced(kv map[string]interface{}) (interface{}, error) {
+	r := OrbitalReinforced{}
+	var err error
+	if r.AggressorId, err = kvInt(kv, "aggressorID"); err != nil {
+		return nil, err
+	}
+	if r.AggressorCorpId, err = kvInt(kv, "aggressorCorpID"); err != nil {
+		return nil, err
+	}
+	if r.AggressorAllianceId, err = kvInt(kv, "aggressorAllianceID"); err != nil {
+		return nil, err
+	}
+	if r.SolarSystemId, err = kvInt(kv, "solarSystemID"); err != nil {
+		return nil, err
+	}
+	if r.PlanetId, err = kvInt(kv, "planetID"); err != nil {
+		return nil, err
+	}
+	if r.TypeId, err = kvInt(kv, "typeID"); err != nil {
+		return nil, err
+	}
+	if r.ReinforceExitTime, err = kvTime(kv, "reinforceExitTime"); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,104 @@
+package golink
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotifications(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(notificationsXML), APICredentials{})
+	ns, err := a.CharNotifications(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ns) != 2 {
+		t.Fatalf("Wrong number of notifications. Got %+v", ns)
+	}
+	if ns[0] != (cNotification{Id: 304084087, TypeId: 16, SenderId: 797400947, SenderName: "CCP Garthagk", Sent: time.Unix(1271764800, 0).UTC(), Read: false}) {
+		t.Errorf("Wrong notification returned. Got %+v", ns[0])
+	}
+	if !ns[1].Read {
+		t.Errorf("Notification should be read. Got %+v", ns[1])
+	}
+}
+
+func TestNotificationTexts(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(notificationTextsXML), APICredentials{})
+	texts, err := a.CharNotificationTexts(1, 374044083, 374106507)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(texts.Texts) != 2 || len(texts.Missing) != 1 || texts.Missing[0] != 374106508 {
+		t.Fatalf("Wrong texts returned. Got %+v", texts)
+	}
+	body, err := DecodeNotification(27, texts.Texts[374106507])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != (WarDeclaration{AgainstId: 673381830, DeclaredById: 98105019, Cost: 50000000, DelayHours: 24, HostileState: false}) {
+		t.Errorf("Wrong war declaration decoded. Got %+v", body)
+	}
+	body, err = DecodeNotification(1234, texts.Texts[374044083])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kv, ok := body.(map[string]interface{}); !ok || kv["shipTypeID"] != int64(606) {
+		t.Errorf("Unknown type not decoded to a map. Got %+v", body)
+	}
+}
+
+func TestDecodeStructureAttack(t *testing.T) {
+	body, err := DecodeNotification(75, "aggressorAllianceID: 1\naggressorCorpID: 2\naggressorID: 3\narmorValue: 1.0\nhullValue: 1.0\nmoonID: 40000001\nshieldValue: 0.52\nsolarSystemID: 30000001\ntypeID: 16213\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != (StructureAttack{AggressorId: 3, AggressorCorpId: 2, AggressorAllianceId: 1, SolarSystemId: 30000001, MoonId: 40000001, TypeId: 16213, ShieldValue: 0.52, ArmorValue: 1, HullValue: 1}) {
+		t.Errorf("Wrong structure attack decoded. Got %+v", body)
+	}
+}
+
+// Bodies come from the server, so malformed ones must be reported, not panic.
+func TestDecodeNotificationMalformed(t *testing.T) {
+	for _, text := range []string{
+		"no separator here",
+		"againstID: 1\nbroken line",
+		"cost:50000000",
+		"- a\nb: 1",
+	} {
+		if _, err := DecodeNotification(5, text); err == nil {
+			t.Errorf("Expected an error decoding %q", text)
+		}
+	}
+	if _, err := DecodeNotification(5, "- 1\n- 2"); err == nil {
+		t.Error("Expected an error decoding a list body")
+	}
+}
+
+const (
+	notificationsXML = `
+<result>
+    <rowset name="notifications" key="notificationID" columns="notificationID,typeID,senderID,senderName,sentDate,read">
+        <row notificationID="304084087" typeID="16" senderID="797400947" senderName="CCP Garthagk" sentDate="2010-04-20 12:00:00" read="0" />
+        <row notificationID="303795523" typeID="16" senderID="671216635" senderName="Some Corp" sentDate="2010-04-19 12:00:00" read="1" />
+    </rowset>
+</result>
+`
+	notificationTextsXML = `
+<result>
+    <rowset name="notifications" key="notificationID" columns="notificationID">
+        <row notificationID="374044083"><![CDATA[
+isHouseWarmingGift: 1
+shipTypeID: 606
+]]></row>
+        <row notificationID="374106507"><![CDATA[
+againstID: 673381830
+cost: 50000000
+declaredByID: 98105019
+delayHours: 24
+hostileState: 0
+]]></row>
+    </rowset>
+    <missingIDs>374106508</missingIDs>
+</result>
+`
+)
@@ -0,0 +1,284 @@
+package golink
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type cPlanetaryColony struct {
+	SolarSystemId              int64
+	SolarSystemName            string
+	PlanetId                   int64
+	PlanetName                 string
+	PlanetTypeId               int64
+	OwnerId                    int64
+	OwnerName                  string
+	LastUpdate                 time.Time
+	UpgradeLevel, NumberOfPins int64
+}
+
+type cPinContent struct {
+	TypeId, Quantity int64
+}
+
+type cPlanetaryPin struct {
+	Id, TypeId, SchematicId     int64
+	LastLaunch, Install, Expiry time.Time
+	CycleTime, QuantityPerCycle int64
+	Longitude, Latitude         float64
+	Contents                    []cPinContent
+}
+
+type cPlanetaryRoute struct {
+	Id, SourcePinId, DestinationPinId int64
+	ContentTypeId, Quantity           int64
+	Waypoints                         []int64
+}
+
+type cPlanetaryLink struct {
+	SourcePinId, DestinationPinId, Level int64
+}
+
+type cExtractorStatus struct {
+	PinId, TypeId int64
+	Expiry        time.Time
+	Remaining     time.Duration // Negative once the extractor has expired.
+}
+
+// A colony with its pins, the links between them and the routes moving
+// commodities along those links.
+type cPlanetGraph struct {
+	Colony     cPlanetaryColony
+	Pins       map[int64]cPlanetaryPin
+	Links      []cPlanetaryLink
+	Routes     []cPlanetaryRoute
+	Neighbors  map[int64][]int64
+	Extractors []cExtractorStatus
+}
+
+func (a *CredentialedAPI) CharPlanetaryColonies(charId int64) ([]cPlanetaryColony, error) {
+	result, err := a.Get("char/PlanetaryColonies", url.Values{"characterID": []string{formatId(charId)}})
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cPlanetaryColony
+	for _, row := range rowset.FindAll("row") {
+		c := cPlanetaryColony{}
+		if c.SolarSystemId, err = getIntAttr(row, "solarSystemID"); err != nil {
+			return nil, err
+		}
+		c.SolarSystemName = first(row.Get("solarSystemName"))
+		if c.PlanetId, err = getIntAttr(row, "planetID"); err != nil {
+			return nil, err
+		}
+		c.PlanetName = first(row.Get("planetName"))
+		if c.PlanetTypeId, err = getIntAttr(row, "planetTypeID"); err != nil {
+			return nil, err
+		}
+		if c.OwnerId, err = getIntAttr(row, "ownerID"); err != nil {
+			return nil, err
+		}
+		c.OwnerName = first(row.Get("ownerName"))
+		if c.LastUpdate, err = getTimeAttr(row, "lastUpdate"); err != nil {
+			return nil, err
+		}
+		if c.UpgradeLevel, err = getIntAttr(row, "upgradeLevel"); err != nil {
+			return nil, err
+		}
+		if c.NumberOfPins, err = getIntAttr(row, "numberOfPins"); err != nil {
+			return nil, err
+		}
+		r = append(r, c)
+	}
+	return r, nil
+}
+
+// Returns the pins on a planet. The API returns one row per stored commodity,
+// which are merged into the Contents of a single pin.
+func (a *CredentialedAPI) CharPlanetaryPins(charId, planetId int64) ([]cPlanetaryPin, error) {
+	pins, _, err := a.charPlanetaryPins(charId, planetId)
+	return pins, err
+}
+
+// Like CharPlanetaryPins, also returning the server time of the response.
+func (a *CredentialedAPI) charPlanetaryPins(charId, planetId int64) ([]cPlanetaryPin, time.Time, error) {
+	result, now, err := a.getResult("char/PlanetaryPins", url.Values{"characterID": []string{formatId(charId)}, "planetID": []string{formatId(planetId)}})
+	if err != nil {
+		return nil, now, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, now, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cPlanetaryPin
+	index := make(map[int64]int)
+	for _, row := range rowset.FindAll("row") {
+		p := cPlanetaryPin{}
+		if p.Id, err = getIntAttr(row, "pinID"); err != nil {
+			return nil, now, err
+		}
+		content := cPinContent{}
+		if content.TypeId, err = getIntAttr(row, "contentTypeID"); err != nil {
+			return nil, now, err
+		}
+		if content.Quantity, err = getIntAttr(row, "contentQuantity"); err != nil {
+			return nil, now, err
+		}
+		if i, ok := index[p.Id]; ok {
+			if content.TypeId != 0 {
+				r[i].Contents = append(r[i].Contents, content)
+			}
+			continue
+		}
+		if p.TypeId, err = getIntAttr(row, "typeID"); err != nil {
+			return nil, now, err
+		}
+		if p.SchematicId, err = getIntAttr(row, "schematicID"); err != nil {
+			return nil, now, err
+		}
+		if p.LastLaunch, err = getTimeAttr(row, "lastLaunchTime"); err != nil {
+			return nil, now, err
+		}
+		if p.Install, err = getTimeAttr(row, "installTime"); err != nil {
+			return nil, now, err
+		}
+		if p.Expiry, err = getTimeAttr(row, "expiryTime"); err != nil {
+			return nil, now, err
+		}
+		if p.CycleTime, err = getIntAttr(row, "cycleTime"); err != nil {
+			return nil, now, err
+		}
+		if p.QuantityPerCycle, err = getIntAttr(row, "quantityPerCycle"); err != nil {
+			return nil, now, err
+		}
+		if p.Longitude, err = getFloatAttr(row, "longitude"); err != nil {
+			return nil, now, err
+		}
+		if p.Latitude, err = getFloatAttr(row, "latitude"); err != nil {
+			return nil, now, err
+		}
+		if content.TypeId != 0 {
+			p.Contents = append(p.Contents, content)
+		}
+		index[p.Id] = len(r)
+		r = append(r, p)
+	}
+	return r, now, nil
+}
+
+func (a *CredentialedAPI) CharPlanetaryRoutes(charId, planetId int64) ([]cPlanetaryRoute, error) {
+	result, err := a.Get("char/PlanetaryRoutes", url.Values{"characterID": []string{formatId(charId)}, "planetID": []string{formatId(planetId)}})
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cPlanetaryRoute
+	for _, row := range rowset.FindAll("row") {
+		rt := cPlanetaryRoute{}
+		if rt.Id, err = getIntAttr(row, "routeID"); err != nil {
+			return nil, err
+		}
+		if rt.SourcePinId, err = getIntAttr(row, "sourcePinID"); err != nil {
+			return nil, err
+		}
+		if rt.DestinationPinId, err = getIntAttr(row, "destinationPinID"); err != nil {
+			return nil, err
+		}
+		if rt.ContentTypeId, err = getIntAttr(row, "contentTypeID"); err != nil {
+			return nil, err
+		}
+		if rt.Quantity, err = getIntAttr(row, "quantity"); err != nil {
+			return nil, err
+		}
+		for i := 1; i <= 5; i++ {
+			w, err := getIntAttr(row, fmt.Sprintf("waypoint%v", i))
+			if err != nil {
+				return nil, err
+			}
+			if w != 0 {
+				rt.Waypoints = append(rt.Waypoints, w)
+			}
+		}
+		r = append(r, rt)
+	}
+	return r, nil
+}
+
+func (a *CredentialedAPI) CharPlanetaryLinks(charId, planetId int64) ([]cPlanetaryLink, error) {
+	result, err := a.Get("char/PlanetaryLinks", url.Values{"characterID": []string{formatId(charId)}, "planetID": []string{formatId(planetId)}})
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cPlanetaryLink
+	for _, row := range rowset.FindAll("row") {
+		l := cPlanetaryLink{}
+		if l.SourcePinId, err = getIntAttr(row, "sourcePinID"); err != nil {
+			return nil, err
+		}
+		if l.DestinationPinId, err = getIntAttr(row, "destinationPinID"); err != nil {
+			return nil, err
+		}
+		if l.Level, err = getIntAttr(row, "linkLevel"); err != nil {
+			return nil, err
+		}
+		r = append(r, l)
+	}
+	return r, nil
+}
+
+// Fetches every colony of a character along with its pins, links and routes.
+// Extractor expiry is reported relative to the server time of the pins
+// response, or to the local time if that is unknown.
+func (a *CredentialedAPI) CharPlanetGraphs(charId int64) ([]cPlanetGraph, error) {
+	colonies, err := a.CharPlanetaryColonies(charId)
+	if err != nil {
+		return nil, err
+	}
+	var r []cPlanetGraph
+	for _, c := range colonies {
+		pins, now, err := a.charPlanetaryPins(charId, c.PlanetId)
+		if err != nil {
+			return nil, err
+		}
+		if now.IsZero() {
+			now = time.Now().UTC()
+		}
+		links, err := a.CharPlanetaryLinks(charId, c.PlanetId)
+		if err != nil {
+			return nil, err
+		}
+		routes, err := a.CharPlanetaryRoutes(charId, c.PlanetId)
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, buildPlanetGraph(c, pins, links, routes, now))
+	}
+	return r, nil
+}
+
+func buildPlanetGraph(c cPlanetaryColony, pins []cPlanetaryPin, links []cPlanetaryLink, routes []cPlanetaryRoute, now time.Time) cPlanetGraph {
+	g := cPlanetGraph{Colony: c, Pins: make(map[int64]cPlanetaryPin), Links: links, Routes: routes, Neighbors: make(map[int64][]int64)}
+	for _, p := range pins {
+		g.Pins[p.Id] = p
+		if !p.Expiry.IsZero() {
+			g.Extractors = append(g.Extractors, cExtractorStatus{PinId: p.Id, TypeId: p.TypeId, Expiry: p.Expiry, Remaining: p.Expiry.Sub(now)})
+		}
+	}
+	for _, l := range links {
+		g.Neighbors[l.SourcePinId] = append(g.Neighbors[l.SourcePinId], l.DestinationPinId)
+		g.Neighbors[l.DestinationPinId] = append(g.Neighbors[l.DestinationPinId], l.SourcePinId)
+	}
+	return g
+}
@@ -0,0 +1,106 @@
+package golink
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlanetGraphs(t *testing.T) {
+	a := NewCredentialedAPI(apiFuncTester(func(path string, params url.Values) string {
+		return map[string]string{
+			"char/PlanetaryColonies": planetaryColoniesXML,
+			"char/PlanetaryPins":     planetaryPinsXML,
+			"char/PlanetaryLinks":    planetaryLinksXML,
+			"char/PlanetaryRoutes":   planetaryRoutesXML,
+		}[path]
+	}), APICredentials{})
+	graphs, err := a.CharPlanetGraphs(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(graphs) != 1 {
+		t.Fatalf("Wrong number of planets. Got %+v", graphs)
+	}
+	g := graphs[0]
+	if g.Colony.PlanetId != 40023691 || g.Colony.PlanetName != "Dodixie IX" || g.Colony.NumberOfPins != 3 {
+		t.Errorf("Wrong colony returned. Got %+v", g.Colony)
+	}
+	if len(g.Pins) != 3 || len(g.Pins[1009528541060].Contents) != 2 || g.Pins[1009528541060].Contents[1] != (cPinContent{TypeId: 2397, Quantity: 150}) {
+		t.Errorf("Wrong pins returned. Got %+v", g.Pins)
+	}
+	if n := g.Neighbors[1009528541060]; len(n) != 2 {
+		t.Errorf("Wrong neighbors returned. Got %+v", g.Neighbors)
+	}
+	if len(g.Routes) != 1 || len(g.Routes[0].Waypoints) != 1 || g.Routes[0].Quantity != 3000 {
+		t.Errorf("Wrong routes returned. Got %+v", g.Routes)
+	}
+	if len(g.Extractors) != 1 || g.Extractors[0].PinId != 1009528541061 || g.Extractors[0].Expiry != time.Unix(1370606400, 0).UTC() {
+		t.Errorf("Wrong extractors returned. Got %+v", g.Extractors)
+	}
+}
+
+func TestExtractorRemaining(t *testing.T) {
+	expiry := time.Unix(1370606400, 0).UTC()
+	g := buildPlanetGraph(cPlanetaryColony{}, []cPlanetaryPin{{Id: 1, Expiry: expiry}, {Id: 2}}, nil, nil, expiry.Add(-90*time.Minute))
+	if len(g.Extractors) != 1 || g.Extractors[0].Remaining != 90*time.Minute {
+		t.Errorf("Wrong extractors returned. Got %+v", g.Extractors)
+	}
+}
+
+func TestPlanetGraphsResponseTime(t *testing.T) {
+	body := func(xml string) string {
+		return xml[strings.Index(xml, "<rowset"):strings.Index(xml, "</result>")]
+	}
+	api := NewAPI("", nil, keyInfoFetcher("Character", 1<<1, URLPathFetcher(map[string]string{
+		"char/PlanetaryColonies": body(planetaryColoniesXML),
+		"char/PlanetaryPins":     body(planetaryPinsXML),
+		"char/PlanetaryLinks":    body(planetaryLinksXML),
+		"char/PlanetaryRoutes":   body(planetaryRoutesXML),
+	})))
+	api.lastCurrentTime = time.Unix(1370606400, 0).UTC()
+	graphs, err := NewCredentialedAPI(api, APICredentials{}).CharPlanetGraphs(898901870)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Unix(1370606400, 0).Sub(time.Date(2009, 10, 18, 17, 5, 31, 0, time.UTC))
+	if len(graphs) != 1 || len(graphs[0].Extractors) != 1 || graphs[0].Extractors[0].Remaining != want {
+		t.Errorf("Extractor not measured from the pins response. Got %+v", graphs)
+	}
+}
+
+const (
+	planetaryColoniesXML = `
+<result>
+    <rowset name="colonies" key="planetID" columns="solarSystemID,solarSystemName,planetID,planetName,planetTypeID,planetTypeName,ownerID,ownerName,lastUpdate,upgradeLevel,numberOfPins">
+        <row solarSystemID="30002659" solarSystemName="Dodixie" planetID="40023691" planetName="Dodixie IX" planetTypeID="2016" planetTypeName="Planet (Barren)" ownerID="1801683792" ownerName="Pilot" lastUpdate="2013-06-05 20:16:58" upgradeLevel="0" numberOfPins="3" />
+    </rowset>
+</result>
+`
+	planetaryPinsXML = `
+<result>
+    <rowset name="pins" key="pinID" columns="pinID,typeID,typeName,schematicID,lastLaunchTime,cycleTime,quantityPerCycle,installTime,expiryTime,contentTypeID,contentTypeName,contentQuantity,longitude,latitude">
+        <row pinID="1009528541060" typeID="2524" typeName="Barren Command Center" schematicID="0" lastLaunchTime="0001-01-01 00:00:00" cycleTime="0" quantityPerCycle="0" installTime="0001-01-01 00:00:00" expiryTime="0001-01-01 00:00:00" contentTypeID="2268" contentTypeName="Aqueous Liquids" contentQuantity="20" longitude="1.5" latitude="2.5" />
+        <row pinID="1009528541060" typeID="2524" typeName="Barren Command Center" schematicID="0" lastLaunchTime="0001-01-01 00:00:00" cycleTime="0" quantityPerCycle="0" installTime="0001-01-01 00:00:00" expiryTime="0001-01-01 00:00:00" contentTypeID="2397" contentTypeName="Industrial Fibers" contentQuantity="150" longitude="1.5" latitude="2.5" />
+        <row pinID="1009528541061" typeID="2848" typeName="Barren Extractor Control Unit" schematicID="0" lastLaunchTime="2013-06-05 20:16:58" cycleTime="30" quantityPerCycle="6000" installTime="2013-06-05 20:16:58" expiryTime="2013-06-07 12:00:00" contentTypeID="0" contentTypeName="" contentQuantity="0" longitude="1.6" latitude="2.6" />
+        <row pinID="1009528541062" typeID="2541" typeName="Barren Storage Facility" schematicID="0" lastLaunchTime="0001-01-01 00:00:00" cycleTime="0" quantityPerCycle="0" installTime="0001-01-01 00:00:00" expiryTime="0001-01-01 00:00:00" contentTypeID="0" contentTypeName="" contentQuantity="0" longitude="1.7" latitude="2.7" />
+    </rowset>
+</result>
+`
+	planetaryLinksXML = `
+<result>
+    <rowset name="links" key="sourcePinID,destinationPinID" columns="sourcePinID,destinationPinID,linkLevel">
+        <row sourcePinID="1009528541060" destinationPinID="1009528541061" linkLevel="0" />
+        <row sourcePinID="1009528541062" destinationPinID="1009528541060" linkLevel="1" />
+    </rowset>
+</result>
+`
+	planetaryRoutesXML = `
+<result>
+    <rowset name="routes" key="routeID" columns="routeID,sourcePinID,destinationPinID,contentTypeID,contentTypeName,quantity,waypoint1,waypoint2,waypoint3,waypoint4,waypoint5">
+        <row routeID="1" sourcePinID="1009528541061" destinationPinID="1009528541062" contentTypeID="2268" contentTypeName="Aqueous Liquids" quantity="3000" waypoint1="1009528541060" waypoint2="0" waypoint3="0" waypoint4="0" waypoint5="0" />
+    </rowset>
+</result>
+`
+)
@@ -0,0 +1,266 @@
+package golink
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Limits on a single call taking a comma separated list of IDs or names, such
+// as eve/CharacterName or char/MailBodies. The API rejects requests with more
+// than listMaxIds entries, and long parameter lists exceed URL length limits
+// of proxies.
+const (
+	listMaxIds         = 100
+	listMaxParamLength = 1800
+)
+
+// Error codes the API reports when a list of names or IDs contains an invalid
+// entry.
+var invalidListCodes = map[int64]bool{
+	122: true, // Invalid or missing list of names.
+	123: true, // Invalid or missing list of IDs.
+}
+
+type nameLookup struct {
+	path, idAttr, nameAttr, kind string
+}
+
+var (
+	ownerNameLookup = nameLookup{"eve/CharacterName", "characterID", "name", "owner"}
+	typeNameLookup  = nameLookup{"eve/TypeName", "typeID", "typeName", "type"}
+)
+
+// Returns the IDs of the given character names. Unknown names are left out.
+func (a *API) CharacterIDs(names ...string) (map[string]int64, error) {
+	return a.ids("eve/CharacterID", "characterID", "name", names)
+}
+
+// Returns the IDs of the given character, corporation, alliance or faction
+// names. Unknown names are left out.
+func (a *API) OwnerIDs(names ...string) (map[string]int64, error) {
+	return a.ids("eve/OwnerID", "ownerID", "ownerName", names)
+}
+
+func (a *API) ids(path, idAttr, nameAttr string, names []string) (map[string]int64, error) {
+	r := make(map[string]int64)
+	for _, batch := range batchNames(names) {
+		found, err := a.idBatch(path, idAttr, nameAttr, batch)
+		if err != nil {
+			return nil, err
+		}
+		for n, id := range found {
+			r[n] = id
+		}
+	}
+	return r, nil
+}
+
+// Looks up the IDs of a batch of names in a single call.
+func (a *API) idBatch(path, idAttr, nameAttr string, names []string) (map[string]int64, error) {
+	r := make(map[string]int64)
+	result, err := a.Get(path, url.Values{"names": []string{strings.Join(names, ",")}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	for _, row := range rowset.FindAll("row") {
+		id, err := getIntAttr(row, idAttr)
+		if err != nil {
+			return nil, err
+		}
+		if id != 0 {
+			r[first(row.Get(nameAttr))] = id
+		}
+	}
+	return r, nil
+}
+
+// Resolves character, corporation, alliance and other owner IDs to names.
+// Names already in a.Names are not requested again; the rest are fetched in
+// as few eve/CharacterName calls as the API allows. IDs the server does not
+// know are left out of the result. ctx is checked between calls.
+func (a *API) Resolve(ctx context.Context, ids []int64) (map[int64]string, error) {
+	return a.resolve(ctx, ownerNameLookup, ids)
+}
+
+// Resolves item type IDs to names, like Resolve does for owners.
+func (a *API) ResolveTypes(ctx context.Context, ids []int64) (map[int64]string, error) {
+	return a.resolve(ctx, typeNameLookup, ids)
+}
+
+// Resolves character, corporation, alliance and faction names to IDs with
+// eve/OwnerID, caching them like Resolve. Names are matched case-insensitively;
+// the result is keyed by the names as given.
+func (a *API) ResolveNames(ctx context.Context, names []string) (map[string]int64, error) {
+	a.initNames()
+	r := make(map[string]int64)
+	var todo []string
+	for _, n := range names {
+		if v := a.Names.Get(nameCacheKey("id", strings.ToLower(n))); v != nil {
+			id, err := parseIdList(string(v))
+			if err == nil && len(id) == 1 {
+				r[n] = id[0]
+				continue
+			}
+		}
+		todo = append(todo, n)
+	}
+	for _, batch := range batchNames(todo) {
+		if err := ctx.Err(); err != nil {
+			return r, err
+		}
+		found, err := a.idBatch("eve/OwnerID", "ownerID", "ownerName", batch)
+		if err != nil {
+			return r, err
+		}
+		lower := make(map[string]int64)
+		for n, id := range found {
+			lower[strings.ToLower(n)] = id
+		}
+		for _, n := range batch {
+			if id, ok := lower[strings.ToLower(n)]; ok {
+				r[n] = id
+				a.Names.Put(nameCacheKey("id", strings.ToLower(n)), []byte(formatId(id)), cacheForever)
+			}
+		}
+	}
+	return r, nil
+}
+
+func (a *API) resolve(ctx context.Context, l nameLookup, ids []int64) (map[int64]string, error) {
+	a.initNames()
+	r := make(map[int64]string)
+	var todo []int64
+	for _, id := range uniqueIds(ids) {
+		if v := a.Names.Get(nameCacheKey(l.kind, formatId(id))); v != nil {
+			r[id] = string(v)
+			continue
+		}
+		todo = append(todo, id)
+	}
+	for _, batch := range batchIds(todo) {
+		if err := ctx.Err(); err != nil {
+			return r, err
+		}
+		if err := a.resolveBatch(l, batch, r); err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
+// Looks up one batch of names. The API fails the whole call if any ID is
+// invalid, so on such an error the batch is split until the bad IDs are
+// isolated and dropped. Other errors are returned as they are.
+func (a *API) resolveBatch(l nameLookup, batch []int64, r map[int64]string) error {
+	found, err := a.names(l.path, l.idAttr, l.nameAttr, batch)
+	if e, ok := err.(*APIError); ok && invalidListCodes[e.Code] {
+		if len(batch) == 1 {
+			return nil
+		}
+		if err := a.resolveBatch(l, batch[:len(batch)/2], r); err != nil {
+			return err
+		}
+		return a.resolveBatch(l, batch[len(batch)/2:], r)
+	}
+	if err != nil {
+		return err
+	}
+	for id, name := range found {
+		r[id] = name
+		a.Names.Put(nameCacheKey(l.kind, formatId(id)), []byte(name), cacheForever)
+	}
+	return nil
+}
+
+// Looks up the names of a batch of IDs in a single call.
+func (a *API) names(path, idAttr, nameAttr string, ids []int64) (map[int64]string, error) {
+	r := make(map[int64]string)
+	result, err := a.Get(path, url.Values{"ids": []string{joinIds(ids)}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	for _, row := range rowset.FindAll("row") {
+		id, err := getIntAttr(row, idAttr)
+		if err != nil {
+			return nil, err
+		}
+		if r[id], err = getStrAttr(row, nameAttr); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// APIs built without NewAPI get an in-memory name cache on first use.
+func (a *API) initNames() {
+	if a.Names == nil {
+		a.Names = make(InMemoryAPICache)
+	}
+}
+
+func nameCacheKey(kind, key string) string {
+	return fmt.Sprintf("name#%v#%v", kind, key)
+}
+
+// Splits ids into batches within the per-call ID count and length limits.
+func batchIds(ids []int64) [][]int64 {
+	var r [][]int64
+	var cur []int64
+	length := 0
+	for _, id := range ids {
+		l := len(formatId(id)) + 3 // Encoded comma.
+		if len(cur) > 0 && (len(cur) >= listMaxIds || length+l > listMaxParamLength) {
+			r = append(r, cur)
+			cur, length = nil, 0
+		}
+		cur = append(cur, id)
+		length += l
+	}
+	if len(cur) > 0 {
+		r = append(r, cur)
+	}
+	return r
+}
+
+// Like batchIds, measuring names as they will be encoded in the request.
+func batchNames(names []string) [][]string {
+	var r [][]string
+	var cur []string
+	length := 0
+	for _, n := range names {
+		l := len(url.QueryEscape(n)) + 3 // Encoded comma.
+		if len(cur) > 0 && (len(cur) >= listMaxIds || length+l > listMaxParamLength) {
+			r = append(r, cur)
+			cur, length = nil, 0
+		}
+		cur = append(cur, n)
+		length += l
+	}
+	if len(cur) > 0 {
+		r = append(r, cur)
+	}
+	return r
+}
+
+// Returns ids without zeros and duplicates, in their original order.
+func uniqueIds(ids []int64) []int64 {
+	seen := make(map[int64]bool)
+	var r []int64
+	for _, id := range ids {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			r = append(r, id)
+		}
+	}
+	return r
+}
@@ -0,0 +1,158 @@
+package golink
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const badResolveId = 666
+
+// Answers eve/CharacterName with "Name<id>" for every ID, failing the whole
+// call like the server does if badResolveId is among them.
+func nameFetcher(calls *[]string) URLFetcher {
+	return func(path string, params url.Values) (*http.Response, error) {
+		ids := params.Get("ids")
+		*calls = append(*calls, ids)
+		parsed, _ := parseIdList(ids)
+		var rows bytes.Buffer
+		for _, id := range parsed {
+			if id == badResolveId {
+				return &http.Response{Body: &nopCloser{bytes.NewBufferString(errXML)}}, nil
+			}
+			fmt.Fprintf(&rows, `<row name="Name%v" characterID="%v" />`, id, id)
+		}
+		return &http.Response{Body: &nopCloser{bytes.NewBufferString(`<?xml version='1.0' encoding='UTF-8'?>
+<eveapi version="2">
+    <currentTime>2009-10-18 17:05:31</currentTime>
+    <result><rowset name="characters" key="characterID" columns="name,characterID">` + rows.String() + `</rowset></result>
+    <cachedUntil>2009-10-18 18:05:31</cachedUntil>
+</eveapi>`)}}, nil
+	}
+}
+
+func TestResolveBatches(t *testing.T) {
+	var calls []string
+	a := NewAPI("", nil, nameFetcher(&calls))
+	var ids []int64
+	for i := int64(1); i <= 600; i++ {
+		ids = append(ids, 90000000+i)
+	}
+	names, err := a.Resolve(context.Background(), ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 600 || names[90000001] != "Name90000001" {
+		t.Errorf("Wrong names: %v entries, %q", len(names), names[90000001])
+	}
+	if len(calls) != 6 {
+		t.Errorf("Expected 6 calls, got %v.", len(calls))
+	}
+	for _, c := range calls {
+		if len(url.QueryEscape(c)) > listMaxParamLength || len(strings.Split(c, ",")) > listMaxIds {
+			t.Errorf("Batch too large: %v", c)
+		}
+	}
+
+	calls = nil
+	names, err = a.Resolve(context.Background(), ids[:10])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 0 || len(names) != 10 {
+		t.Errorf("Cached names were fetched again: %v", calls)
+	}
+}
+
+func TestResolveSkipsInvalidIds(t *testing.T) {
+	var calls []string
+	a := NewAPI("", nil, nameFetcher(&calls))
+	names, err := a.Resolve(context.Background(), []int64{1, 2, badResolveId, 4, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 4 || names[5] != "Name5" {
+		t.Errorf("Wrong names: %v", names)
+	}
+	if _, ok := names[badResolveId]; ok {
+		t.Error("Invalid ID was resolved.")
+	}
+}
+
+func TestResolveReturnsServerErrors(t *testing.T) {
+	calls := 0
+	a := NewAPI("", nil, func(path string, params url.Values) (*http.Response, error) {
+		calls++
+		return &http.Response{Body: &nopCloser{bytes.NewBufferString(strings.Replace(errXML, `code="123"`, `code="904"`, 1))}}, nil
+	})
+	_, err := a.Resolve(context.Background(), []int64{1, 2, 3, 4})
+	if e, ok := err.(*APIError); !ok || e.Code != 904 {
+		t.Errorf("Expected the server error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Batch was split on a server error. Got %v calls", calls)
+	}
+}
+
+func TestResolveCancelled(t *testing.T) {
+	var calls []string
+	a := NewAPI("", nil, nameFetcher(&calls))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := a.Resolve(ctx, []int64{1}); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("Cancelled resolve made calls: %v", calls)
+	}
+}
+
+func TestResolveNames(t *testing.T) {
+	a := NewAPI("", nil, URLPathFetcher(map[string]string{
+		"eve/OwnerID": `<rowset name="owners" key="ownerID" columns="ownerName,ownerID">
+<row ownerName="CCP Garthagk" ownerID="797400947" /><row ownerName="Nobody Here" ownerID="0" /></rowset>`,
+	}))
+	ids, err := a.ResolveNames(context.Background(), []string{"ccp garthagk", "Nobody Here"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids["ccp garthagk"] != 797400947 {
+		t.Errorf("Wrong IDs: %v", ids)
+	}
+	a.Client = URLErrFetcher
+	if ids, err = a.ResolveNames(context.Background(), []string{"CCP Garthagk"}); err != nil || ids["CCP Garthagk"] != 797400947 {
+		t.Errorf("Cached ID not used: %v, %v", ids, err)
+	}
+}
+
+func TestOwnerIDsBatches(t *testing.T) {
+	var calls []string
+	a := NewAPI("", nil, func(path string, params url.Values) (*http.Response, error) {
+		calls = append(calls, params.Get("names"))
+		var rows bytes.Buffer
+		for i, n := range strings.Split(params.Get("names"), ",") {
+			fmt.Fprintf(&rows, `<row ownerName="%v" ownerID="%v" />`, n, len(calls)*1000+i+1)
+		}
+		return &http.Response{Body: &nopCloser{bytes.NewBufferString(`<?xml version='1.0' encoding='UTF-8'?>
+<eveapi version="2">
+    <currentTime>2009-10-18 17:05:31</currentTime>
+    <result><rowset name="owners" key="ownerID" columns="ownerName,ownerID">` + rows.String() + `</rowset></result>
+    <cachedUntil>2009-10-18 18:05:31</cachedUntil>
+</eveapi>`)}}, nil
+	})
+	var names []string
+	for i := 0; i < 300; i++ {
+		names = append(names, fmt.Sprintf("Owner %v", i))
+	}
+	ids, err := a.OwnerIDs(names...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) < 2 || len(ids) != 300 {
+		t.Errorf("Expected all names in several calls, got %v IDs in %v calls.", len(ids), len(calls))
+	}
+}
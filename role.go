@@ -0,0 +1,196 @@
+package golink
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A set of corporation roles, as the bit mask used by the API.
+type Role int64
+
+const (
+	RoleDirector                      Role = 1 << 0
+	RolePersonnelManager              Role = 1 << 7
+	RoleAccountant                    Role = 1 << 8
+	RoleSecurityOfficer               Role = 1 << 9
+	RoleFactoryManager                Role = 1 << 10
+	RoleStationManager                Role = 1 << 11
+	RoleAuditor                       Role = 1 << 12
+	RoleHangarCanTake1                Role = 1 << 13
+	RoleHangarCanTake2                Role = 1 << 14
+	RoleHangarCanTake3                Role = 1 << 15
+	RoleHangarCanTake4                Role = 1 << 16
+	RoleHangarCanTake5                Role = 1 << 17
+	RoleHangarCanTake6                Role = 1 << 18
+	RoleHangarCanTake7                Role = 1 << 19
+	RoleHangarCanQuery1               Role = 1 << 20
+	RoleHangarCanQuery2               Role = 1 << 21
+	RoleHangarCanQuery3               Role = 1 << 22
+	RoleHangarCanQuery4               Role = 1 << 23
+	RoleHangarCanQuery5               Role = 1 << 24
+	RoleHangarCanQuery6               Role = 1 << 25
+	RoleHangarCanQuery7               Role = 1 << 26
+	RoleAccountCanTake1               Role = 1 << 27
+	RoleAccountCanTake2               Role = 1 << 28
+	RoleAccountCanTake3               Role = 1 << 29
+	RoleAccountCanTake4               Role = 1 << 30
+	RoleAccountCanTake5               Role = 1 << 31
+	RoleAccountCanTake6               Role = 1 << 32
+	RoleAccountCanTake7               Role = 1 << 33
+	RoleAccountCanQuery1              Role = 1 << 34
+	RoleAccountCanQuery2              Role = 1 << 35
+	RoleAccountCanQuery3              Role = 1 << 36
+	RoleAccountCanQuery4              Role = 1 << 37
+	RoleAccountCanQuery5              Role = 1 << 38
+	RoleAccountCanQuery6              Role = 1 << 39
+	RoleAccountCanQuery7              Role = 1 << 40
+	RoleEquipmentConfig               Role = 1 << 41
+	RoleContainerCanTake1             Role = 1 << 42
+	RoleContainerCanTake2             Role = 1 << 43
+	RoleContainerCanTake3             Role = 1 << 44
+	RoleContainerCanTake4             Role = 1 << 45
+	RoleContainerCanTake5             Role = 1 << 46
+	RoleContainerCanTake6             Role = 1 << 47
+	RoleContainerCanTake7             Role = 1 << 48
+	RoleCanRentOffice                 Role = 1 << 49
+	RoleCanRentFactorySlot            Role = 1 << 50
+	RoleCanRentResearchSlot           Role = 1 << 51
+	RoleJuniorAccountant              Role = 1 << 52
+	RoleStarbaseConfig                Role = 1 << 53
+	RoleTrader                        Role = 1 << 54
+	RoleChatManager                   Role = 1 << 55
+	RoleContractManager               Role = 1 << 56
+	RoleInfrastructureTacticalOfficer Role = 1 << 57
+	RoleStarbaseCaretaker             Role = 1 << 58
+	RoleFittingManager                Role = 1 << 59
+	RoleTerrestrialCombatOfficer      Role = 1 << 60
+	RoleTerrestrialLogisticsOfficer   Role = 1 << 61
+)
+
+// Names of single roles, as used in the roleName column of role rowsets.
+var roleNames = map[Role]string{
+	RoleDirector:                      "roleDirector",
+	RolePersonnelManager:              "rolePersonnelManager",
+	RoleAccountant:                    "roleAccountant",
+	RoleSecurityOfficer:               "roleSecurityOfficer",
+	RoleFactoryManager:                "roleFactoryManager",
+	RoleStationManager:                "roleStationManager",
+	RoleAuditor:                       "roleAuditor",
+	RoleHangarCanTake1:                "roleHangarCanTake1",
+	RoleHangarCanTake2:                "roleHangarCanTake2",
+	RoleHangarCanTake3:                "roleHangarCanTake3",
+	RoleHangarCanTake4:                "roleHangarCanTake4",
+	RoleHangarCanTake5:                "roleHangarCanTake5",
+	RoleHangarCanTake6:                "roleHangarCanTake6",
+	RoleHangarCanTake7:                "roleHangarCanTake7",
+	RoleHangarCanQuery1:               "roleHangarCanQuery1",
+	RoleHangarCanQuery2:               "roleHangarCanQuery2",
+	RoleHangarCanQuery3:               "roleHangarCanQuery3",
+	RoleHangarCanQuery4:               "roleHangarCanQuery4",
+	RoleHangarCanQuery5:               "roleHangarCanQuery5",
+	RoleHangarCanQuery6:               "roleHangarCanQuery6",
+	RoleHangarCanQuery7:               "roleHangarCanQuery7",
+	RoleAccountCanTake1:               "roleAccountCanTake1",
+	RoleAccountCanTake2:               "roleAccountCanTake2",
+	RoleAccountCanTake3:               "roleAccountCanTake3",
+	RoleAccountCanTake4:               "roleAccountCanTake4",
+	RoleAccountCanTake5:               "roleAccountCanTake5",
+	RoleAccountCanTake6:               "roleAccountCanTake6",
+	RoleAccountCanTake7:               "roleAccountCanTake7",
+	RoleAccountCanQuery1:              "roleAccountCanQuery1",
+	RoleAccountCanQuery2:              "roleAccountCanQuery2",
+	RoleAccountCanQuery3:              "roleAccountCanQuery3",
+	RoleAccountCanQuery4:              "roleAccountCanQuery4",
+	RoleAccountCanQuery5:              "roleAccountCanQuery5",
+	RoleAccountCanQuery6:              "roleAccountCanQuery6",
+	RoleAccountCanQuery7:              "roleAccountCanQuery7",
+	RoleEquipmentConfig:               "roleEquipmentConfig",
+	RoleContainerCanTake1:             "roleContainerCanTake1",
+	RoleContainerCanTake2:             "roleContainerCanTake2",
+	RoleContainerCanTake3:             "roleContainerCanTake3",
+	RoleContainerCanTake4:             "roleContainerCanTake4",
+	RoleContainerCanTake5:             "roleContainerCanTake5",
+	RoleContainerCanTake6:             "roleContainerCanTake6",
+	RoleContainerCanTake7:             "roleContainerCanTake7",
+	RoleCanRentOffice:                 "roleCanRentOffice",
+	RoleCanRentFactorySlot:            "roleCanRentFactorySlot",
+	RoleCanRentResearchSlot:           "roleCanRentResearchSlot",
+	RoleJuniorAccountant:              "roleJuniorAccountant",
+	RoleStarbaseConfig:                "roleStarbaseConfig",
+	RoleTrader:                        "roleTrader",
+	RoleChatManager:                   "roleChatManager",
+	RoleContractManager:               "roleContractManager",
+	RoleInfrastructureTacticalOfficer: "roleInfrastructureTacticalOfficer",
+	RoleStarbaseCaretaker:             "roleStarbaseCaretaker",
+	RoleFittingManager:                "roleFittingManager",
+	RoleTerrestrialCombatOfficer:      "roleTerrestrialCombatOfficer",
+	RoleTerrestrialLogisticsOfficer:   "roleTerrestrialLogisticsOfficer",
+}
+
+// Returns the names of the roles in the set, separated by "|". Unknown bits
+// are shown as hex.
+func (r Role) String() string {
+	if r == 0 {
+		return "none"
+	}
+	var names []string
+	for _, single := range r.Split() {
+		if n, ok := roleNames[single]; ok {
+			names = append(names, n)
+		} else {
+			names = append(names, fmt.Sprintf("%#x", int64(single)))
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// Reports whether every role in other is in r.
+func (r Role) Has(other Role) bool {
+	return r&other == other
+}
+
+func (r Role) Add(other Role) Role {
+	return r | other
+}
+
+func (r Role) Remove(other Role) Role {
+	return r &^ other
+}
+
+// Returns the individual roles in the set, lowest bit first.
+func (r Role) Split() []Role {
+	var ret []Role
+	for bit := uint(0); bit < 63; bit++ {
+		if single := Role(1) << bit; r&single != 0 {
+			ret = append(ret, single)
+		}
+	}
+	return ret
+}
+
+// Returns the roles in newer that are not in r, and those in r that are not in
+// newer.
+func (r Role) Diff(newer Role) (added, removed Role) {
+	return newer &^ r, r &^ newer
+}
+
+// Returns the role with the given roleName, as found in role rowsets.
+func ParseRoleName(name string) (Role, error) {
+	for role, n := range roleNames {
+		if n == name {
+			return role, nil
+		}
+	}
+	return 0, fmt.Errorf("Unknown role name: %v", name)
+}
+
+// Returns the names of all known roles, sorted.
+func RoleNames() []string {
+	var ret []string
+	for _, n := range roleNames {
+		ret = append(ret, n)
+	}
+	sort.Strings(ret)
+	return ret
+}
@@ -0,0 +1,65 @@
+package golink
+
+import (
+	"testing"
+)
+
+func TestRoleMethods(t *testing.T) {
+	r := RoleDirector.Add(RoleAuditor)
+	if !r.Has(RoleDirector) || !r.Has(RoleDirector|RoleAuditor) || r.Has(RoleTrader) {
+		t.Errorf("Wrong Has results for %v", r)
+	}
+	if r.Remove(RoleDirector) != RoleAuditor {
+		t.Errorf("Remove failed. Got %v", r.Remove(RoleDirector))
+	}
+	if s := r.String(); s != "roleDirector|roleAuditor" {
+		t.Errorf("Wrong string. Got %v", s)
+	}
+	if s := (RoleTrader | 2).String(); s != "0x2|roleTrader" {
+		t.Errorf("Wrong string for unknown bit. Got %v", s)
+	}
+	if len(RoleNames()) != len(roleNames) {
+		t.Errorf("Wrong number of role names.")
+	}
+}
+
+func TestRoleDiff(t *testing.T) {
+	before := RoleDirector | RoleHangarCanTake1
+	after := RoleHangarCanTake1 | RoleAccountant
+	added, removed := before.Diff(after)
+	if added != RoleAccountant || removed != RoleDirector {
+		t.Errorf("Wrong diff. Added %v, removed %v", added, removed)
+	}
+}
+
+func TestParseRoleName(t *testing.T) {
+	r, err := ParseRoleName("roleHangarCanQuery3")
+	if err != nil || r != 4194304 {
+		t.Errorf("Wrong role parsed. Got %v, %v", r, err)
+	}
+	if _, err := ParseRoleName("roleNonsense"); err == nil {
+		t.Error("Expected an error for an unknown role name.")
+	}
+}
+
+func TestParseRoleRowsetByName(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(`
+<result>
+    <rowset name="members" key="characterID" columns="characterID,name">
+        <row characterID="1" name="Tester">
+            <rowset name="roles" key="roleName" columns="roleName">
+                <row roleName="roleDirector" />
+                <row roleName="roleTrader" />
+            </rowset>
+        </row>
+    </rowset>
+</result>
+`), APICredentials{})
+	members, err := a.CorpMemberSecurity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if members[0].Roles != RoleDirector|RoleTrader {
+		t.Errorf("Wrong roles parsed. Got %v", members[0].Roles)
+	}
+}
@@ -0,0 +1,112 @@
+package golink
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type eServerStatus struct {
+	Open          bool
+	OnlinePlayers int64
+}
+
+type eCallGroup struct {
+	Id                int64
+	Name, Description string
+}
+
+// A key-protected API call. Type is "char" or "corp", like aKInfo.Type and the
+// first part of the call's path.
+type eCall struct {
+	AccessMask  int64
+	Type, Name  string
+	GroupId     int64
+	Description string
+}
+
+type eCallList struct {
+	Groups []eCallGroup
+	Calls  []eCall
+}
+
+// Returns whether the server is open and how many players are online. This
+// call needs no credentials.
+func (a *API) ServerStatus() (eServerStatus, error) {
+	r := eServerStatus{}
+	result, err := a.Get("server/ServerStatus", url.Values{}, nil)
+	if err != nil {
+		return r, err
+	}
+	if r.Open, err = getBoolValue(result, "serverOpen"); err != nil {
+		return r, err
+	}
+	if r.OnlinePlayers, err = getIntValue(result, "onlinePlayers"); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// Returns the key-protected calls and the access mask bit each needs. This call
+// needs no credentials.
+func (a *API) CallList() (eCallList, error) {
+	r := eCallList{}
+	result, err := a.Get("api/CallList", url.Values{}, nil)
+	if err != nil {
+		return r, err
+	}
+	groups, calls := findRowset(result, "callGroups"), findRowset(result, "calls")
+	if groups == nil || calls == nil {
+		return r, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	for _, row := range groups.FindAll("row") {
+		g := eCallGroup{}
+		if g.Id, err = getIntAttr(row, "groupID"); err != nil {
+			return r, err
+		}
+		g.Name = first(row.Get("name"))
+		g.Description = first(row.Get("description"))
+		r.Groups = append(r.Groups, g)
+	}
+	for _, row := range calls.FindAll("row") {
+		c := eCall{}
+		if c.AccessMask, err = getIntAttr(row, "accessMask"); err != nil {
+			return r, err
+		}
+		var ok bool
+		if c.Type, ok = key_types[first(row.Get("type"))]; !ok {
+			return r, fmt.Errorf("Unknown call type: %v", first(row.Get("type")))
+		}
+		if c.Name, err = getStrAttr(row, "name"); err != nil {
+			return r, err
+		}
+		if c.GroupId, err = getIntAttr(row, "groupID"); err != nil {
+			return r, err
+		}
+		c.Description = first(row.Get("description"))
+		r.Calls = append(r.Calls, c)
+	}
+	return r, nil
+}
+
+// Returns the access mask bit needed for a call path such as
+// "char/WalletJournal", and false if the call is not in the list.
+func (l eCallList) AccessBit(path string) (int64, bool) {
+	parts := strings.SplitN(path, "/", 2)
+	if len(parts) != 2 {
+		return 0, false
+	}
+	for _, c := range l.Calls {
+		if c.Type == parts[0] && c.Name == parts[1] {
+			return c.AccessMask, true
+		}
+	}
+	return 0, false
+}
+
+// Returns whether a key with the given access mask may make the call. Calls not
+// in the list are not protected by the mask and always permitted.
+func (l eCallList) Permits(accessMask int64, path string) bool {
+	bit, ok := l.AccessBit(path)
+	return !ok || accessMask&bit != 0
+}
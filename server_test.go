@@ -0,0 +1,57 @@
+package golink
+
+import (
+	"testing"
+)
+
+func TestServerStatus(t *testing.T) {
+	a := NewAPI("", nil, URLPathFetcher(map[string]string{"server/ServerStatus": serverStatusXML}))
+	status, err := a.ServerStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != (eServerStatus{Open: true, OnlinePlayers: 38102}) {
+		t.Errorf("Wrong server status. Got %+v", status)
+	}
+}
+
+func TestCallList(t *testing.T) {
+	a := NewAPI("", nil, URLPathFetcher(map[string]string{"api/CallList": callListXML}))
+	l, err := a.CallList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Groups) != 2 || l.Groups[0] != (eCallGroup{Id: 1, Name: "Account and Market", Description: "Market Orders, account balance and journal history."}) {
+		t.Errorf("Wrong call groups. Got %+v", l.Groups)
+	}
+	if len(l.Calls) != 3 || l.Calls[0] != (eCall{AccessMask: 2097152, Type: "char", Name: "WalletJournal", GroupId: 1, Description: "Wallet journal of character."}) {
+		t.Errorf("Wrong calls. Got %+v", l.Calls)
+	}
+	if bit, ok := l.AccessBit("corp/WalletJournal"); !ok || bit != 1048576 {
+		t.Errorf("Wrong access bit for corp/WalletJournal: %v, %v", bit, ok)
+	}
+	if !l.Permits(2097152, "char/WalletJournal") || l.Permits(1048576, "char/WalletJournal") {
+		t.Error("Access mask not checked for char/WalletJournal.")
+	}
+	if !l.Permits(0, "eve/CharacterInfo") {
+		t.Error("Unprotected call was not permitted.")
+	}
+}
+
+const (
+	serverStatusXML = `
+<serverOpen>True</serverOpen>
+<onlinePlayers>38102</onlinePlayers>
+`
+	callListXML = `
+<rowset name="callGroups" key="groupID" columns="groupID,name,description">
+    <row groupID="1" name="Account and Market" description="Market Orders, account balance and journal history." />
+    <row groupID="7" name="Communications" description="Private communications such as contact lists, Eve Mail and Notifications." />
+</rowset>
+<rowset name="calls" key="accessMask,type" columns="accessMask,type,name,groupID,description">
+    <row accessMask="2097152" type="Character" name="WalletJournal" groupID="1" description="Wallet journal of character." />
+    <row accessMask="1048576" type="Corporation" name="WalletJournal" groupID="1" description="Wallet journal for all corporate accounts." />
+    <row accessMask="2048" type="Character" name="MailMessages" groupID="7" description="List of all messages in the characters EVE Mail Inbox." />
+</rowset>
+`
+)
@@ -0,0 +1,185 @@
+package golink
+
+import (
+	"code.google.com/p/go-etree"
+	"fmt"
+	"net/url"
+)
+
+type cStanding struct {
+	FromId   int64
+	FromName string
+	Standing float64
+}
+
+type cNPCStandings struct {
+	Agents, NPCCorporations, Factions []cStanding
+}
+
+type cContact struct {
+	Id          int64
+	Name        string
+	Standing    float64
+	InWatchlist bool // Only set for personal contacts.
+	LabelMask   int64
+	TypeId      int64
+}
+
+type cContactLists struct {
+	Personal, Corporate, Alliance                   []cContact
+	PersonalLabels, CorporateLabels, AllianceLabels map[int64]string
+}
+
+func (a *CredentialedAPI) CharStandings(charId int64) (cNPCStandings, error) {
+	return a.npcStandings("char/Standings", url.Values{"characterID": []string{formatId(charId)}}, "characterNPCStandings")
+}
+
+func (a *CredentialedAPI) CorpStandings() (cNPCStandings, error) {
+	return a.npcStandings("corp/Standings", url.Values{}, "corporationNPCStandings")
+}
+
+func (a *CredentialedAPI) npcStandings(path string, params url.Values, container string) (cNPCStandings, error) {
+	r := cNPCStandings{}
+	result, err := a.Get(path, params)
+	if err != nil {
+		return r, err
+	}
+	standings := result.Find(container)
+	if standings == nil {
+		return r, fmt.Errorf("Unable to find %v in API response.", container)
+	}
+	if r.Agents, err = parseStandingRowset(findRowset(standings, "agents")); err != nil {
+		return r, err
+	}
+	if r.NPCCorporations, err = parseStandingRowset(findRowset(standings, "NPCCorporations")); err != nil {
+		return r, err
+	}
+	if r.Factions, err = parseStandingRowset(findRowset(standings, "factions")); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+func parseStandingRowset(rowset etree.Element) ([]cStanding, error) {
+	var r []cStanding
+	if rowset == nil {
+		return r, nil
+	}
+	var err error
+	for _, row := range rowset.FindAll("row") {
+		s := cStanding{}
+		if s.FromId, err = getIntAttr(row, "fromID"); err != nil {
+			return nil, err
+		}
+		s.FromName = first(row.Get("fromName"))
+		if s.Standing, err = getFloatAttr(row, "standing"); err != nil {
+			return nil, err
+		}
+		r = append(r, s)
+	}
+	return r, nil
+}
+
+// Returns the personal, corporate and alliance contacts visible to a character.
+func (a *CredentialedAPI) CharContactList(charId int64) (cContactLists, error) {
+	return a.contactLists("char/ContactList", url.Values{"characterID": []string{formatId(charId)}})
+}
+
+// Returns the corporate and alliance contacts of the key's corporation.
+func (a *CredentialedAPI) CorpContactList() (cContactLists, error) {
+	return a.contactLists("corp/ContactList", url.Values{})
+}
+
+func (a *CredentialedAPI) contactLists(path string, params url.Values) (cContactLists, error) {
+	r := cContactLists{}
+	result, err := a.Get(path, params)
+	if err != nil {
+		return r, err
+	}
+	if r.Personal, err = parseContactRowset(findRowset(result, "contactList")); err != nil {
+		return r, err
+	}
+	if r.Corporate, err = parseContactRowset(findRowset(result, "corporateContactList")); err != nil {
+		return r, err
+	}
+	if r.Alliance, err = parseContactRowset(findRowset(result, "allianceContactList")); err != nil {
+		return r, err
+	}
+	if r.PersonalLabels, err = parseContactLabelRowset(findRowset(result, "contactLabels")); err != nil {
+		return r, err
+	}
+	if r.CorporateLabels, err = parseContactLabelRowset(findRowset(result, "corporateContactLabels")); err != nil {
+		return r, err
+	}
+	if r.AllianceLabels, err = parseContactLabelRowset(findRowset(result, "allianceContactLabels")); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+func parseContactRowset(rowset etree.Element) ([]cContact, error) {
+	var r []cContact
+	if rowset == nil {
+		return r, nil
+	}
+	var err error
+	for _, row := range rowset.FindAll("row") {
+		c := cContact{}
+		if c.Id, err = getIntAttr(row, "contactID"); err != nil {
+			return nil, err
+		}
+		c.Name = first(row.Get("contactName"))
+		if c.Standing, err = getFloatAttr(row, "standing"); err != nil {
+			return nil, err
+		}
+		c.InWatchlist = first(row.Get("inWatchlist")) == "True"
+		if _, ok := row.Get("labelMask"); ok {
+			if c.LabelMask, err = getIntAttr(row, "labelMask"); err != nil {
+				return nil, err
+			}
+		}
+		if _, ok := row.Get("contactTypeID"); ok {
+			if c.TypeId, err = getIntAttr(row, "contactTypeID"); err != nil {
+				return nil, err
+			}
+		}
+		r = append(r, c)
+	}
+	return r, nil
+}
+
+func parseContactLabelRowset(rowset etree.Element) (map[int64]string, error) {
+	r := make(map[int64]string)
+	if rowset == nil {
+		return r, nil
+	}
+	for _, row := range rowset.FindAll("row") {
+		id, err := getIntAttr(row, "labelID")
+		if err != nil {
+			return nil, err
+		}
+		r[id] = first(row.Get("name"))
+	}
+	return r, nil
+}
+
+// Returns the standing set toward the first of ids found in the contact lists,
+// trying personal contacts first, then corporate, then alliance. Pass a
+// pilot's character, corporation and alliance IDs, in that order, to get the
+// standing the client would apply to them. The second result is false if no
+// contact matches.
+func (c cContactLists) EffectiveStanding(ids ...int64) (float64, bool) {
+	for _, level := range [][]cContact{c.Personal, c.Corporate, c.Alliance} {
+		for _, id := range ids {
+			if id == 0 {
+				continue
+			}
+			for _, contact := range level {
+				if contact.Id == id {
+					return contact.Standing, true
+				}
+			}
+		}
+	}
+	return 0, false
+}
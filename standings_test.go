@@ -0,0 +1,89 @@
+package golink
+
+import (
+	"testing"
+)
+
+func TestStandings(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(charStandingsXML), APICredentials{})
+	s, err := a.CharStandings(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Agents) != 2 || s.Agents[0] != (cStanding{FromId: 3009841, FromName: "Pausent Ansin", Standing: 0.1}) {
+		t.Errorf("Wrong agent standings. Got %+v", s.Agents)
+	}
+	if len(s.NPCCorporations) != 1 || s.NPCCorporations[0].Standing != -0.5 {
+		t.Errorf("Wrong corporation standings. Got %+v", s.NPCCorporations)
+	}
+	if len(s.Factions) != 0 {
+		t.Errorf("Wrong faction standings. Got %+v", s.Factions)
+	}
+}
+
+func TestContactList(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(contactListXML), APICredentials{})
+	c, err := a.CharContactList(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Personal) != 2 || c.Personal[0] != (cContact{Id: 3010913, Name: "Dukas Kalaras", Standing: 0, InWatchlist: false, LabelMask: 0, TypeId: 1376}) {
+		t.Errorf("Wrong personal contacts. Got %+v", c.Personal)
+	}
+	if !c.Personal[1].InWatchlist || c.Personal[1].LabelMask != 2 {
+		t.Errorf("Wrong personal contact. Got %+v", c.Personal[1])
+	}
+	if len(c.Corporate) != 2 || len(c.Alliance) != 1 || c.PersonalLabels[2] != "Friends" || len(c.CorporateLabels) != 0 {
+		t.Errorf("Wrong contact lists. Got %+v", c)
+	}
+
+	if s, ok := c.EffectiveStanding(797400947); !ok || s != 10 {
+		t.Errorf("Personal standing should win. Got %v, %v", s, ok)
+	}
+	if s, ok := c.EffectiveStanding(123, 98000001, 99000001); !ok || s != -5 {
+		t.Errorf("Corporate standing toward the pilot's corp should apply. Got %v, %v", s, ok)
+	}
+	if s, ok := c.EffectiveStanding(123, 456, 99000002); !ok || s != -10 {
+		t.Errorf("Alliance standing should apply. Got %v, %v", s, ok)
+	}
+	if _, ok := c.EffectiveStanding(123, 456, 789); ok {
+		t.Error("No standing should be found.")
+	}
+}
+
+const (
+	charStandingsXML = `
+<result>
+    <characterNPCStandings>
+        <rowset name="agents" key="fromID" columns="fromID,fromName,standing">
+            <row fromID="3009841" fromName="Pausent Ansin" standing="0.1" />
+            <row fromID="3009846" fromName="Charie Octienne" standing="0.19" />
+        </rowset>
+        <rowset name="NPCCorporations" key="fromID" columns="fromID,fromName,standing">
+            <row fromID="1000061" fromName="Freedom Extension" standing="-0.5" />
+        </rowset>
+        <rowset name="factions" key="fromID" columns="fromID,fromName,standing" />
+    </characterNPCStandings>
+</result>
+`
+	contactListXML = `
+<result>
+    <rowset name="contactList" key="contactID" columns="contactID,contactName,standing,inWatchlist,labelMask,contactTypeID">
+        <row contactID="3010913" contactName="Dukas Kalaras" standing="0" inWatchlist="False" labelMask="0" contactTypeID="1376" />
+        <row contactID="797400947" contactName="CCP Garthagk" standing="10" inWatchlist="True" labelMask="2" contactTypeID="1383" />
+    </rowset>
+    <rowset name="contactLabels" key="labelID" columns="labelID,name">
+        <row labelID="2" name="Friends" />
+    </rowset>
+    <rowset name="corporateContactList" key="contactID" columns="contactID,contactName,standing,labelMask">
+        <row contactID="797400947" contactName="CCP Garthagk" standing="-10" labelMask="0" />
+        <row contactID="98000001" contactName="Enemy Corp" standing="-5" labelMask="0" />
+    </rowset>
+    <rowset name="corporateContactLabels" key="labelID" columns="labelID,name" />
+    <rowset name="allianceContactList" key="contactID" columns="contactID,contactName,standing,labelMask">
+        <row contactID="99000002" contactName="Enemy Alliance" standing="-10" labelMask="0" />
+    </rowset>
+    <rowset name="allianceContactLabels" key="labelID" columns="labelID,name" />
+</result>
+`
+)
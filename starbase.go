@@ -0,0 +1,198 @@
+package golink
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"time"
+)
+
+type StarbaseState int64
+
+const (
+	StarbaseUnanchored StarbaseState = iota
+	StarbaseOffline                  // Anchored but not online.
+	StarbaseOnlining
+	StarbaseReinforced
+	StarbaseOnline
+)
+
+var starbaseStateNames = map[StarbaseState]string{
+	StarbaseUnanchored: "unanchored",
+	StarbaseOffline:    "offline",
+	StarbaseOnlining:   "onlining",
+	StarbaseReinforced: "reinforced",
+	StarbaseOnline:     "online",
+}
+
+func (s StarbaseState) String() string {
+	if n, ok := starbaseStateNames[s]; ok {
+		return n
+	}
+	return fmt.Sprintf("StarbaseState(%d)", int64(s))
+}
+
+type cStarbase struct {
+	Id, TypeId, LocationId, MoonId int64
+	State                          StarbaseState
+	StateTimestamp, Online         time.Time
+	StandingOwnerId                int64
+}
+
+type cStarbaseGeneralSettings struct {
+	UsageFlags, DeployFlags                       int64
+	AllowCorporationMembers, AllowAllianceMembers bool
+}
+
+type cStarbaseCombatSettings struct {
+	UseStandingsFrom               int64
+	OnStandingDrop                 float64
+	OnStatusDropEnabled            bool
+	OnStatusDrop                   float64
+	OnAggression, OnCorporationWar bool
+}
+
+type cStarbaseDetail struct {
+	State                  StarbaseState
+	StateTimestamp, Online time.Time
+	General                cStarbaseGeneralSettings
+	Combat                 cStarbaseCombatSettings
+	Fuel                   map[int64]int64
+}
+
+func (a *CredentialedAPI) CorpStarbaseList() ([]cStarbase, error) {
+	result, err := a.Get("corp/StarbaseList", url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []cStarbase
+	for _, row := range rowset.FindAll("row") {
+		s := cStarbase{}
+		if s.Id, err = getIntAttr(row, "itemID"); err != nil {
+			return nil, err
+		}
+		if s.TypeId, err = getIntAttr(row, "typeID"); err != nil {
+			return nil, err
+		}
+		if s.LocationId, err = getIntAttr(row, "locationID"); err != nil {
+			return nil, err
+		}
+		if s.MoonId, err = getIntAttr(row, "moonID"); err != nil {
+			return nil, err
+		}
+		state, err := getIntAttr(row, "state")
+		if err != nil {
+			return nil, err
+		}
+		s.State = StarbaseState(state)
+		if s.StateTimestamp, err = getOptTimeAttr(row, "stateTimestamp"); err != nil {
+			return nil, err
+		}
+		if s.Online, err = getOptTimeAttr(row, "onlineTimestamp"); err != nil {
+			return nil, err
+		}
+		if s.StandingOwnerId, err = getIntAttr(row, "standingOwnerID"); err != nil {
+			return nil, err
+		}
+		r = append(r, s)
+	}
+	return r, nil
+}
+
+func (a *CredentialedAPI) CorpStarbaseDetail(itemId int64) (cStarbaseDetail, error) {
+	r := cStarbaseDetail{Fuel: make(map[int64]int64)}
+	result, err := a.Get("corp/StarbaseDetail", url.Values{"itemID": []string{formatId(itemId)}})
+	if err != nil {
+		return r, err
+	}
+	state, err := getIntValue(result, "state")
+	if err != nil {
+		return r, err
+	}
+	r.State = StarbaseState(state)
+	if temp, err := getStrValue(result, "stateTimestamp"); err == nil && temp != "" {
+		if r.StateTimestamp, err = parseEveTs(temp); err != nil {
+			return r, err
+		}
+	}
+	if temp, err := getStrValue(result, "onlineTimestamp"); err == nil && temp != "" {
+		if r.Online, err = parseEveTs(temp); err != nil {
+			return r, err
+		}
+	}
+
+	general := result.Find("generalSettings")
+	if general == nil {
+		return r, fmt.Errorf("Unable to find generalSettings in API response.")
+	}
+	if r.General.UsageFlags, err = getIntValue(general, "usageFlags"); err != nil {
+		return r, err
+	}
+	if r.General.DeployFlags, err = getIntValue(general, "deployFlags"); err != nil {
+		return r, err
+	}
+	if r.General.AllowCorporationMembers, err = getFlagValue(general, "allowCorporationMembers"); err != nil {
+		return r, err
+	}
+	if r.General.AllowAllianceMembers, err = getFlagValue(general, "allowAllianceMembers"); err != nil {
+		return r, err
+	}
+
+	combat := result.Find("combatSettings")
+	if combat == nil {
+		return r, fmt.Errorf("Unable to find combatSettings in API response.")
+	}
+	if e := combat.Find("useStandingsFrom"); e != nil {
+		if r.Combat.UseStandingsFrom, err = getIntAttr(e, "ownerID"); err != nil {
+			return r, err
+		}
+	}
+	if e := combat.Find("onStandingDrop"); e != nil {
+		if r.Combat.OnStandingDrop, err = getFloatAttr(e, "standing"); err != nil {
+			return r, err
+		}
+	}
+	if e := combat.Find("onStatusDrop"); e != nil {
+		r.Combat.OnStatusDropEnabled = first(e.Get("enabled")) == "1"
+		if r.Combat.OnStatusDrop, err = getFloatAttr(e, "standing"); err != nil {
+			return r, err
+		}
+	}
+	if e := combat.Find("onAggression"); e != nil {
+		r.Combat.OnAggression = first(e.Get("enabled")) == "1"
+	}
+	if e := combat.Find("onCorporationWar"); e != nil {
+		r.Combat.OnCorporationWar = first(e.Get("enabled")) == "1"
+	}
+
+	if fuel := findRowset(result, "fuel"); fuel != nil {
+		for _, row := range fuel.FindAll("row") {
+			typeId, err := getIntAttr(row, "typeID")
+			if err != nil {
+				return r, err
+			}
+			if r.Fuel[typeId], err = getIntAttr(row, "quantity"); err != nil {
+				return r, err
+			}
+		}
+	}
+	return r, nil
+}
+
+// Returns how many hours the tower can keep running, given how much of each
+// fuel type it consumes per hour. Fuel types with no consumption are ignored;
+// if nothing is consumed the result is +Inf.
+func (d cStarbaseDetail) FuelHoursRemaining(perHour map[int64]int64) float64 {
+	hours := math.Inf(1)
+	for typeId, rate := range perHour {
+		if rate <= 0 {
+			continue
+		}
+		hours = math.Min(hours, float64(d.Fuel[typeId])/float64(rate))
+	}
+	return hours
+}
@@ -0,0 +1,85 @@
+package golink
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestStarbaseList(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(starbaseListXML), APICredentials{})
+	bases, err := a.CorpStarbaseList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bases) != 2 {
+		t.Fatalf("Wrong number of starbases. Got %+v", bases)
+	}
+	if bases[0] != (cStarbase{Id: 150354725, TypeId: 12235, LocationId: 30001984, MoonId: 40126341, State: StarbaseOnline, StateTimestamp: time.Unix(1244213339, 0).UTC(), Online: time.Unix(1243351727, 0).UTC(), StandingOwnerId: 150337897}) {
+		t.Errorf("Wrong starbase returned. Got %+v", bases[0])
+	}
+	if bases[1].State != StarbaseOffline || !bases[1].Online.IsZero() {
+		t.Errorf("Wrong starbase returned. Got %+v", bases[1])
+	}
+}
+
+func TestStarbaseDetail(t *testing.T) {
+	a := NewCredentialedAPI(apiTester(starbaseDetailXML), APICredentials{})
+	d, err := a.CorpStarbaseDetail(150354725)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.State != StarbaseOnline || d.Online != time.Unix(1243351727, 0).UTC() {
+		t.Errorf("Wrong state returned. Got %+v", d)
+	}
+	if d.General != (cStarbaseGeneralSettings{UsageFlags: 3, DeployFlags: 0, AllowCorporationMembers: true, AllowAllianceMembers: false}) {
+		t.Errorf("Wrong general settings returned. Got %+v", d.General)
+	}
+	if d.Combat != (cStarbaseCombatSettings{UseStandingsFrom: 150337897, OnStandingDrop: -1, OnStatusDropEnabled: false, OnStatusDrop: 0, OnAggression: true, OnCorporationWar: true}) {
+		t.Errorf("Wrong combat settings returned. Got %+v", d.Combat)
+	}
+	if len(d.Fuel) != 2 || d.Fuel[4051] != 400 || d.Fuel[16275] != 2000 {
+		t.Errorf("Wrong fuel returned. Got %+v", d.Fuel)
+	}
+	if h := d.FuelHoursRemaining(map[int64]int64{4051: 40, 16275: 100, 24592: 0}); h != 10 {
+		t.Errorf("Wrong fuel hours. Got %v", h)
+	}
+	if h := d.FuelHoursRemaining(nil); !math.IsInf(h, 1) {
+		t.Errorf("Wrong fuel hours without consumption. Got %v", h)
+	}
+}
+
+const (
+	starbaseListXML = `
+<result>
+    <rowset name="starbases" key="itemID" columns="itemID,typeID,locationID,moonID,state,stateTimestamp,onlineTimestamp,standingOwnerID">
+        <row itemID="150354725" typeID="12235" locationID="30001984" moonID="40126341" state="4" stateTimestamp="2009-06-05 14:48:59" onlineTimestamp="2009-05-26 15:28:47" standingOwnerID="150337897" />
+        <row itemID="150354726" typeID="12236" locationID="30001984" moonID="40126342" state="1" stateTimestamp="" onlineTimestamp="" standingOwnerID="150337897" />
+    </rowset>
+</result>
+`
+	starbaseDetailXML = `
+<result>
+    <state>4</state>
+    <stateTimestamp>2009-06-05 14:48:59</stateTimestamp>
+    <onlineTimestamp>2009-05-26 15:28:47</onlineTimestamp>
+    <generalSettings>
+        <usageFlags>3</usageFlags>
+        <deployFlags>0</deployFlags>
+        <allowCorporationMembers>1</allowCorporationMembers>
+        <allowAllianceMembers>0</allowAllianceMembers>
+    </generalSettings>
+    <combatSettings>
+        <useStandingsFrom ownerID="150337897" />
+        <onStandingDrop standing="-1" />
+        <onStatusDrop enabled="0" standing="0" />
+        <onAggression enabled="1" />
+        <onCorporationWar enabled="1" />
+    </combatSettings>
+    <rowset name="fuel" key="typeID" columns="typeID,quantity">
+        <row typeID="4051" quantity="400" />
+        <row typeID="16275" quantity="2000" />
+    </rowset>
+</result>
+`
+)
@@ -0,0 +1,393 @@
+package golink
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Reference data changes only with game patches, so it is kept in API.Static
+// for much longer than the server's cachedUntil.
+const staticCacheDuration = 7 * 24 * time.Hour
+
+type eRefType struct {
+	Id   int64
+	Name string
+}
+
+type eSkillRequirement struct {
+	TypeId, Level int64
+}
+
+type eSkill struct {
+	TypeId                               int64
+	Name                                 string
+	GroupId                              int64
+	Published                            bool
+	Description                          string
+	Rank                                 int64
+	PrimaryAttribute, SecondaryAttribute string
+	RequiredSkills                       []eSkillRequirement
+	Bonuses                              map[string]string
+}
+
+type eSkillGroup struct {
+	Id     int64
+	Name   string
+	Skills []eSkill
+}
+
+type eConquerableStation struct {
+	Id              int64
+	Name            string
+	TypeId          int64
+	SolarSystemId   int64
+	CorporationId   int64
+	CorporationName string
+}
+
+// Indexes of the reference data, built on first lookup and rebuilt when the
+// cache entry they were built from expires. Like the rest of API, they are not
+// safe for concurrent use.
+type staticIndex struct {
+	refTypesExpiry, skillsExpiry, stationsExpiry time.Time
+
+	refTypes     map[int64]eRefType
+	refTypeNames map[string]eRefType
+	skills       map[int64]eSkill
+	skillNames   map[string]eSkill
+	stations     map[int64]eConquerableStation
+	stationNames map[string]eConquerableStation
+}
+
+// Returns the wallet journal reference types. This call needs no credentials.
+func (a *API) RefTypes() ([]eRefType, error) {
+	r, _, err := a.staticRefTypes()
+	return r, err
+}
+
+// Like RefTypes, also returning when the cached data expires.
+func (a *API) staticRefTypes() (r []eRefType, expiry time.Time, err error) {
+	expiry, err = a.cachedStatic("eve/RefTypes", &r, func() (err error) {
+		r, err = a.refTypes()
+		return
+	})
+	return
+}
+
+// Returns all skills by group. This call needs no credentials.
+func (a *API) SkillTree() ([]eSkillGroup, error) {
+	r, _, err := a.staticSkillTree()
+	return r, err
+}
+
+// Like SkillTree, also returning when the cached data expires.
+func (a *API) staticSkillTree() (r []eSkillGroup, expiry time.Time, err error) {
+	expiry, err = a.cachedStatic("eve/SkillTree", &r, func() (err error) {
+		r, err = a.skillTree()
+		return
+	})
+	return
+}
+
+// Returns the player-owned outposts and conquerable stations. This call needs
+// no credentials.
+func (a *API) ConquerableStations() ([]eConquerableStation, error) {
+	r, _, err := a.staticConquerableStations()
+	return r, err
+}
+
+// Like ConquerableStations, also returning when the cached data expires.
+func (a *API) staticConquerableStations() (r []eConquerableStation, expiry time.Time, err error) {
+	expiry, err = a.cachedStatic("eve/ConquerableStationList", &r, func() (err error) {
+		r, err = a.conquerableStations()
+		return
+	})
+	return
+}
+
+// Looks up a reference type by refTypeID.
+func (a *API) RefType(id int64) (eRefType, error) {
+	if err := a.loadRefTypes(); err != nil {
+		return eRefType{}, err
+	}
+	r, ok := a.static.refTypes[id]
+	if !ok {
+		return r, fmt.Errorf("Unknown reference type %v.", id)
+	}
+	return r, nil
+}
+
+// Looks up a reference type by name, ignoring case.
+func (a *API) RefTypeByName(name string) (eRefType, error) {
+	if err := a.loadRefTypes(); err != nil {
+		return eRefType{}, err
+	}
+	r, ok := a.static.refTypeNames[strings.ToLower(name)]
+	if !ok {
+		return r, fmt.Errorf("Unknown reference type %v.", name)
+	}
+	return r, nil
+}
+
+// Looks up a skill by typeID.
+func (a *API) Skill(typeId int64) (eSkill, error) {
+	if err := a.loadSkills(); err != nil {
+		return eSkill{}, err
+	}
+	r, ok := a.static.skills[typeId]
+	if !ok {
+		return r, fmt.Errorf("Unknown skill %v.", typeId)
+	}
+	return r, nil
+}
+
+// Looks up a skill by name, ignoring case.
+func (a *API) SkillByName(name string) (eSkill, error) {
+	if err := a.loadSkills(); err != nil {
+		return eSkill{}, err
+	}
+	r, ok := a.static.skillNames[strings.ToLower(name)]
+	if !ok {
+		return r, fmt.Errorf("Unknown skill %v.", name)
+	}
+	return r, nil
+}
+
+// Looks up a conquerable station by stationID.
+func (a *API) ConquerableStation(id int64) (eConquerableStation, error) {
+	if err := a.loadStations(); err != nil {
+		return eConquerableStation{}, err
+	}
+	r, ok := a.static.stations[id]
+	if !ok {
+		return r, fmt.Errorf("Unknown conquerable station %v.", id)
+	}
+	return r, nil
+}
+
+// Looks up a conquerable station by name, ignoring case.
+func (a *API) ConquerableStationByName(name string) (eConquerableStation, error) {
+	if err := a.loadStations(); err != nil {
+		return eConquerableStation{}, err
+	}
+	r, ok := a.static.stationNames[strings.ToLower(name)]
+	if !ok {
+		return r, fmt.Errorf("Unknown conquerable station %v.", name)
+	}
+	return r, nil
+}
+
+func (a *API) loadRefTypes() error {
+	if a.static.refTypes != nil && time.Now().Before(a.static.refTypesExpiry) {
+		return nil
+	}
+	types, expiry, err := a.staticRefTypes()
+	if err != nil {
+		return err
+	}
+	a.static.refTypesExpiry = expiry
+	a.static.refTypes = make(map[int64]eRefType)
+	a.static.refTypeNames = make(map[string]eRefType)
+	for _, t := range types {
+		a.static.refTypes[t.Id] = t
+		a.static.refTypeNames[strings.ToLower(t.Name)] = t
+	}
+	return nil
+}
+
+func (a *API) loadSkills() error {
+	if a.static.skills != nil && time.Now().Before(a.static.skillsExpiry) {
+		return nil
+	}
+	groups, expiry, err := a.staticSkillTree()
+	if err != nil {
+		return err
+	}
+	a.static.skillsExpiry = expiry
+	a.static.skills = make(map[int64]eSkill)
+	a.static.skillNames = make(map[string]eSkill)
+	for _, g := range groups {
+		for _, s := range g.Skills {
+			a.static.skills[s.TypeId] = s
+			a.static.skillNames[strings.ToLower(s.Name)] = s
+		}
+	}
+	return nil
+}
+
+func (a *API) loadStations() error {
+	if a.static.stations != nil && time.Now().Before(a.static.stationsExpiry) {
+		return nil
+	}
+	stations, expiry, err := a.staticConquerableStations()
+	if err != nil {
+		return err
+	}
+	a.static.stationsExpiry = expiry
+	a.static.stations = make(map[int64]eConquerableStation)
+	a.static.stationNames = make(map[string]eConquerableStation)
+	for _, s := range stations {
+		a.static.stations[s.Id] = s
+		a.static.stationNames[strings.ToLower(s.Name)] = s
+	}
+	return nil
+}
+
+// An entry in API.Static. APICache does not report expiry, so it is stored
+// along with the data.
+type staticEntry struct {
+	Expiry time.Time
+	Data   json.RawMessage
+}
+
+// Fills v from API.Static, or with fetch and stores the result there. Returns
+// when the entry expires.
+func (a *API) cachedStatic(path string, v interface{}, fetch func() error) (time.Time, error) {
+	if a.Static == nil {
+		a.Static = make(InMemoryAPICache)
+	}
+	key := "static#" + path
+	if data := a.Static.Get(key); data != nil {
+		var e staticEntry
+		if json.Unmarshal(data, &e) == nil && json.Unmarshal(e.Data, v) == nil {
+			return e.Expiry, nil
+		}
+	}
+	if err := fetch(); err != nil {
+		return time.Time{}, err
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	e := staticEntry{Expiry: time.Now().Add(staticCacheDuration), Data: raw}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return time.Time{}, err
+	}
+	a.Static.Put(key, data, staticCacheDuration)
+	return e.Expiry, nil
+}
+
+func (a *API) refTypes() ([]eRefType, error) {
+	result, err := a.Get("eve/RefTypes", url.Values{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []eRefType
+	for _, row := range rowset.FindAll("row") {
+		t := eRefType{}
+		if t.Id, err = getIntAttr(row, "refTypeID"); err != nil {
+			return nil, err
+		}
+		if t.Name, err = getStrAttr(row, "refTypeName"); err != nil {
+			return nil, err
+		}
+		r = append(r, t)
+	}
+	return r, nil
+}
+
+func (a *API) skillTree() ([]eSkillGroup, error) {
+	result, err := a.Get("eve/SkillTree", url.Values{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	rowset := findRowset(result, "skillGroups")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []eSkillGroup
+	// A group may be split over several rows; they are merged by groupID.
+	index := make(map[int64]int)
+	for _, row := range rowset.FindAll("row") {
+		id, err := getIntAttr(row, "groupID")
+		if err != nil {
+			return nil, err
+		}
+		i, ok := index[id]
+		if !ok {
+			i = len(r)
+			index[id] = i
+			r = append(r, eSkillGroup{Id: id, Name: first(row.Get("groupName"))})
+		}
+		skills := findRowset(row, "skills")
+		if skills == nil {
+			continue
+		}
+		for _, srow := range skills.FindAll("row") {
+			s := eSkill{Bonuses: make(map[string]string)}
+			if s.TypeId, err = getIntAttr(srow, "typeID"); err != nil {
+				return nil, err
+			}
+			s.Name = first(srow.Get("typeName"))
+			s.GroupId = id
+			s.Published = first(srow.Get("published")) == "1"
+			s.Description, _ = getStrValue(srow, "description")
+			if s.Rank, err = getIntValue(srow, "rank"); err != nil {
+				return nil, err
+			}
+			if attrs := srow.Find("requiredAttributes"); attrs != nil {
+				s.PrimaryAttribute, _ = getStrValue(attrs, "primaryAttribute")
+				s.SecondaryAttribute, _ = getStrValue(attrs, "secondaryAttribute")
+			}
+			if reqs := findRowset(srow, "requiredSkills"); reqs != nil {
+				for _, rrow := range reqs.FindAll("row") {
+					req := eSkillRequirement{}
+					if req.TypeId, err = getIntAttr(rrow, "typeID"); err != nil {
+						return nil, err
+					}
+					if req.Level, err = getIntAttr(rrow, "skillLevel"); err != nil {
+						return nil, err
+					}
+					s.RequiredSkills = append(s.RequiredSkills, req)
+				}
+			}
+			if bonuses := findRowset(srow, "skillBonusCollection"); bonuses != nil {
+				for _, brow := range bonuses.FindAll("row") {
+					s.Bonuses[first(brow.Get("bonusType"))] = first(brow.Get("bonusValue"))
+				}
+			}
+			r[i].Skills = append(r[i].Skills, s)
+		}
+	}
+	return r, nil
+}
+
+func (a *API) conquerableStations() ([]eConquerableStation, error) {
+	result, err := a.Get("eve/ConquerableStationList", url.Values{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	rowset := result.Find("rowset")
+	if rowset == nil {
+		return nil, fmt.Errorf("Unable to extract rowset from API response.")
+	}
+	var r []eConquerableStation
+	for _, row := range rowset.FindAll("row") {
+		s := eConquerableStation{}
+		if s.Id, err = getIntAttr(row, "stationID"); err != nil {
+			return nil, err
+		}
+		s.Name = first(row.Get("stationName"))
+		if s.TypeId, err = getIntAttr(row, "stationTypeID"); err != nil {
+			return nil, err
+		}
+		if s.SolarSystemId, err = getIntAttr(row, "solarSystemID"); err != nil {
+			return nil, err
+		}
+		if s.CorporationId, err = getIntAttr(row, "corporationID"); err != nil {
+			return nil, err
+		}
+		s.CorporationName = first(row.Get("corporationName"))
+		r = append(r, s)
+	}
+	return r, nil
+}
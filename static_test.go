@@ -0,0 +1,181 @@
+package golink
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func staticFetcher(calls *int) URLFetcher {
+	fetcher := URLPathFetcher(map[string]string{
+		"eve/RefTypes":               refTypesXML,
+		"eve/SkillTree":              skillTreeXML,
+		"eve/ConquerableStationList": conquerableStationsXML,
+	})
+	return func(path string, params url.Values) (*http.Response, error) {
+		*calls++
+		return fetcher(path, params)
+	}
+}
+
+func TestRefTypes(t *testing.T) {
+	calls := 0
+	a := NewAPI("", nil, staticFetcher(&calls))
+	if rt, err := a.RefType(10); err != nil || rt != (eRefType{10, "Player Donation"}) {
+		t.Errorf("Wrong reference type: %+v, %v", rt, err)
+	}
+	if rt, err := a.RefTypeByName("bounty prizes"); err != nil || rt.Id != 85 {
+		t.Errorf("Wrong reference type: %+v, %v", rt, err)
+	}
+	if _, err := a.RefType(9999); err == nil {
+		t.Error("Unknown reference type was found.")
+	}
+	if calls != 1 {
+		t.Errorf("Expected one call, got %v", calls)
+	}
+
+	// A fresh API sharing the static cache needs no calls at all.
+	b := NewAPI("", nil, staticFetcher(&calls))
+	b.Static = a.Static
+	if types, err := b.RefTypes(); err != nil || len(types) != 3 {
+		t.Errorf("Wrong reference types: %+v, %v", types, err)
+	}
+	if calls != 1 {
+		t.Errorf("Static cache was not used. Got %v calls", calls)
+	}
+}
+
+func TestStaticIndexExpiry(t *testing.T) {
+	calls := 0
+	a := NewAPI("", nil, staticFetcher(&calls))
+	if _, err := a.RefType(10); err != nil {
+		t.Fatal(err)
+	}
+
+	// An index built from an existing cache entry expires with that entry.
+	b := NewAPI("", nil, staticFetcher(&calls))
+	b.Static = a.Static
+	if _, err := b.RefType(10); err != nil {
+		t.Fatal(err)
+	}
+	if !b.static.refTypesExpiry.Equal(a.static.refTypesExpiry) {
+		t.Errorf("Index expiry not taken from the cache entry. Got %v, want %v", b.static.refTypesExpiry, a.static.refTypesExpiry)
+	}
+
+	// Once the cached data has expired, the index is rebuilt from a new fetch.
+	a.Cache = make(InMemoryAPICache)
+	a.Static = make(InMemoryAPICache)
+	a.static.refTypesExpiry = time.Now().Add(-time.Second)
+	if _, err := a.RefType(10); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("Expired index was not rebuilt. Got %v calls", calls)
+	}
+}
+
+func TestSkillTree(t *testing.T) {
+	calls := 0
+	a := NewAPI("", nil, staticFetcher(&calls))
+	groups, err := a.SkillTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 2 || groups[0].Name != "Corporation Management" || len(groups[0].Skills) != 2 {
+		t.Fatalf("Split skill group was not merged. Got %+v", groups)
+	}
+	s, err := a.SkillByName("ANCHORING")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.TypeId != 11584 || s.GroupId != 266 || !s.Published || s.Rank != 3 || s.PrimaryAttribute != "memory" || s.SecondaryAttribute != "charisma" ||
+		s.Description != "Skill at Anchoring Deployables." || len(s.RequiredSkills) != 1 || s.RequiredSkills[0] != (eSkillRequirement{3354, 2}) || s.Bonuses["canNotBeTrainedOnTrial"] != "1" {
+		t.Errorf("Wrong skill. Got %+v", s)
+	}
+	if s, err = a.Skill(3348); err != nil || s.Name != "Leadership" || len(s.RequiredSkills) != 0 {
+		t.Errorf("Wrong skill: %+v, %v", s, err)
+	}
+}
+
+func TestConquerableStations(t *testing.T) {
+	calls := 0
+	a := NewAPI("", nil, staticFetcher(&calls))
+	s, err := a.ConquerableStation(61000001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := eConquerableStation{Id: 61000001, Name: "DB1R-4 II - duperTurtle 1", TypeId: 21645, SolarSystemId: 30004181, CorporationId: 1000135, CorporationName: "Serpentis Corporation"}
+	if s != expected {
+		t.Errorf("Wrong station. Got %+v", s)
+	}
+	if s, err = a.ConquerableStationByName("ARG-3R II - Test"); err != nil || s.Id != 61000002 {
+		t.Errorf("Wrong station: %+v, %v", s, err)
+	}
+}
+
+const (
+	refTypesXML = `
+<rowset name="refTypes" key="refTypeID" columns="refTypeID,refTypeName">
+    <row refTypeID="0" refTypeName="Undefined" />
+    <row refTypeID="10" refTypeName="Player Donation" />
+    <row refTypeID="85" refTypeName="Bounty Prizes" />
+</rowset>
+`
+	skillTreeXML = `
+<rowset name="skillGroups" key="groupID" columns="groupName,groupID">
+    <row groupName="Corporation Management" groupID="266">
+        <rowset name="skills" key="typeID" columns="typeName,groupID,typeID,published">
+            <row typeName="Anchoring" groupID="266" typeID="11584" published="1">
+                <description>Skill at Anchoring Deployables.</description>
+                <rank>3</rank>
+                <rowset name="requiredSkills" key="typeID" columns="typeID,skillLevel">
+                    <row typeID="3354" skillLevel="2" />
+                </rowset>
+                <requiredAttributes>
+                    <primaryAttribute>memory</primaryAttribute>
+                    <secondaryAttribute>charisma</secondaryAttribute>
+                </requiredAttributes>
+                <rowset name="skillBonusCollection" key="bonusType" columns="bonusType,bonusValue">
+                    <row bonusType="canNotBeTrainedOnTrial" bonusValue="1" />
+                </rowset>
+            </row>
+        </rowset>
+    </row>
+    <row groupName="Leadership" groupID="258">
+        <rowset name="skills" key="typeID" columns="typeName,groupID,typeID,published">
+            <row typeName="Leadership" groupID="258" typeID="3348" published="1">
+                <description>Basic leadership.</description>
+                <rank>1</rank>
+                <rowset name="requiredSkills" key="typeID" columns="typeID,skillLevel" />
+                <requiredAttributes>
+                    <primaryAttribute>willpower</primaryAttribute>
+                    <secondaryAttribute>charisma</secondaryAttribute>
+                </requiredAttributes>
+                <rowset name="skillBonusCollection" key="bonusType" columns="bonusType,bonusValue" />
+            </row>
+        </rowset>
+    </row>
+    <row groupName="Corporation Management" groupID="266">
+        <rowset name="skills" key="typeID" columns="typeName,groupID,typeID,published">
+            <row typeName="CFO Training" groupID="266" typeID="3369" published="1">
+                <description>Skill at managing corp finances.</description>
+                <rank>3</rank>
+                <rowset name="requiredSkills" key="typeID" columns="typeID,skillLevel" />
+                <requiredAttributes>
+                    <primaryAttribute>memory</primaryAttribute>
+                    <secondaryAttribute>charisma</secondaryAttribute>
+                </requiredAttributes>
+                <rowset name="skillBonusCollection" key="bonusType" columns="bonusType,bonusValue" />
+            </row>
+        </rowset>
+    </row>
+</rowset>
+`
+	conquerableStationsXML = `
+<rowset name="outposts" key="stationID" columns="stationID,stationName,stationTypeID,solarSystemID,corporationID,corporationName">
+    <row stationID="61000001" stationName="DB1R-4 II - duperTurtle 1" stationTypeID="21645" solarSystemID="30004181" corporationID="1000135" corporationName="Serpentis Corporation" />
+    <row stationID="61000002" stationName="ARG-3R II - Test" stationTypeID="21644" solarSystemID="30003459" corporationID="1000135" corporationName="Serpentis Corporation" />
+</rowset>
+`
+)
@@ -61,14 +61,17 @@ func getBoolValue(e etree.Element, child string) (bool, error) {
 	return false, fmt.Errorf("Unknown bool literal value: %v", c.Text())
 }
 
-func extractKeyval(data string) map[string]string {
-	pairs := strings.FieldsFunc(data, func(r rune) bool { return r == '\n' })
-	ret := make(map[string]string)
-	for _, s := range pairs {
-		r := strings.Split(s, ": ")
-		ret[r[0]] = r[1]
+// Like getBoolValue, for elements holding 0 or 1 rather than False or True.
+func getFlagValue(e etree.Element, child string) (bool, error) {
+	v, err := getStrValue(e, child)
+	if err != nil {
+		return false, err
+	}
+	i, err := strconv.ParseInt(v, 0, 64)
+	if err != nil {
+		return false, err
 	}
-	return ret
+	return i != 0, nil
 }
 
 func parseMSDate(data string) (time.Time, error) {
@@ -79,3 +82,83 @@ func parseMSDate(data string) (time.Time, error) {
 	i = (i / 10000000) - 11644473600
 	return time.Unix(i, 0), nil
 }
+
+func findRowset(e etree.Element, name string) etree.Element {
+	for _, r := range e.FindAll("rowset") {
+		if n, _ := r.Get("name"); n == name {
+			return r
+		}
+	}
+	return nil
+}
+
+func getStrAttr(e etree.Element, attr string) (string, error) {
+	v, ok := e.Get(attr)
+	if !ok {
+		return "", fmt.Errorf("Missing attribute: %v", attr)
+	}
+	return v, nil
+}
+
+func getIntAttr(e etree.Element, attr string) (int64, error) {
+	v, ok := e.Get(attr)
+	if !ok {
+		return 0, fmt.Errorf("Missing attribute: %v", attr)
+	}
+	return strconv.ParseInt(v, 0, 64)
+}
+
+func getFloatAttr(e etree.Element, attr string) (float64, error) {
+	v, ok := e.Get(attr)
+	if !ok {
+		return 0, fmt.Errorf("Missing attribute: %v", attr)
+	}
+	return strconv.ParseFloat(v, 64)
+}
+
+func getTimeAttr(e etree.Element, attr string) (time.Time, error) {
+	v, ok := e.Get(attr)
+	if !ok {
+		return time.Time{}, fmt.Errorf("Missing attribute: %v", attr)
+	}
+	return parseEveTs(v)
+}
+
+// Like getTimeAttr, but a missing or empty attribute yields the zero time.
+func getOptTimeAttr(e etree.Element, attr string) (time.Time, error) {
+	v, _ := e.Get(attr)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	return parseEveTs(v)
+}
+
+func formatId(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+func joinIds(ids []int64) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = formatId(id)
+	}
+	return strings.Join(s, ",")
+}
+
+// Parses a comma separated list of IDs, as used by several mail and
+// notification columns. An empty string yields an empty list.
+func parseIdList(data string) ([]int64, error) {
+	var ret []int64
+	for _, s := range strings.Split(data, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		i, err := strconv.ParseInt(s, 0, 64)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, i)
+	}
+	return ret, nil
+}
@@ -0,0 +1,385 @@
+package golink
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Error returned when a notification body is not in the YAML subset EVE uses.
+type NotificationSyntaxError struct {
+	Line int
+	Msg  string
+}
+
+func (e *NotificationSyntaxError) Error() string {
+	return fmt.Sprintf("Notification body line %v: %v", e.Line, e.Msg)
+}
+
+type yamlLine struct {
+	num, indent int
+	text        string
+}
+
+type yamlParser struct {
+	lines   []yamlLine
+	pos     int
+	line    int
+	anchors map[string]interface{}
+}
+
+// Parses the subset of YAML used in notification bodies: block maps and
+// lists, flow lists of scalars, quoted and multi-line strings, block scalars,
+// anchors/aliases and "!!" tags. Scalars are returned as int64, float64, bool,
+// string or nil; maps as map[string]interface{} and lists as []interface{}.
+func parseYAML(data string) (interface{}, error) {
+	p := &yamlParser{anchors: make(map[string]interface{})}
+	for i, l := range strings.Split(strings.Replace(data, "\r\n", "\n", -1), "\n") {
+		trimmed := strings.TrimLeft(l, " ")
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, &NotificationSyntaxError{i + 1, "tabs are not allowed for indentation"}
+		}
+		if t := strings.TrimSpace(trimmed); t == "" || t[0] == '#' || t == "---" {
+			continue
+		}
+		p.lines = append(p.lines, yamlLine{num: i + 1, indent: len(l) - len(trimmed), text: strings.TrimRight(trimmed, " \t")})
+	}
+	if len(p.lines) == 0 {
+		return nil, nil
+	}
+	v, err := p.parseNode(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		p.line = p.lines[p.pos].num
+		return nil, p.errorf("unexpected indentation")
+	}
+	return v, nil
+}
+
+func (p *yamlParser) errorf(format string, args ...interface{}) error {
+	return &NotificationSyntaxError{p.line, fmt.Sprintf(format, args...)}
+}
+
+func isListItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func (p *yamlParser) parseNode(indent int) (interface{}, error) {
+	if isListItem(p.lines[p.pos].text) {
+		return p.parseList(indent)
+	}
+	return p.parseMap(indent)
+}
+
+func (p *yamlParser) parseList(indent int) ([]interface{}, error) {
+	ret := make([]interface{}, 0)
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent || (l.indent == indent && !isListItem(l.text)) {
+			break
+		}
+		p.line = l.num
+		if l.indent > indent {
+			return nil, p.errorf("unexpected indentation")
+		}
+		rest := strings.TrimLeft(strings.TrimPrefix(l.text, "-"), " ")
+		if rest == "" {
+			p.pos++
+			v, err := p.parseChild(indent, false)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, v)
+			continue
+		}
+		if _, _, ok := splitKey(rest); ok || isListItem(rest) {
+			// An inline map or list starting on the item's line; reparse the
+			// remainder as if it were its own line at its own indentation.
+			p.lines[p.pos] = yamlLine{num: l.num, indent: l.indent + len(l.text) - len(rest), text: rest}
+			v, err := p.parseNode(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, v)
+			continue
+		}
+		p.pos++
+		v, err := p.parseValue(rest, indent, false)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, v)
+	}
+	return ret, nil
+}
+
+func (p *yamlParser) parseMap(indent int) (map[string]interface{}, error) {
+	ret := make(map[string]interface{})
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent || (l.indent == indent && isListItem(l.text)) {
+			break
+		}
+		p.line = l.num
+		if l.indent > indent {
+			return nil, p.errorf("unexpected indentation")
+		}
+		k, rest, ok := splitKey(l.text)
+		if !ok {
+			return nil, p.errorf("expected \"key: value\", got %q", l.text)
+		}
+		if _, dup := ret[k]; dup {
+			return nil, p.errorf("duplicate key %q", k)
+		}
+		p.pos++
+		var v interface{}
+		var err error
+		if rest == "" {
+			v, err = p.parseChild(indent, true)
+		} else {
+			v, err = p.parseValue(rest, indent, true)
+		}
+		if err != nil {
+			return nil, err
+		}
+		ret[k] = v
+	}
+	return ret, nil
+}
+
+// Parses the block nested under a key or list item at the given indentation,
+// which is nil if there is none. Lists under a map key may share its indent.
+func (p *yamlParser) parseChild(indent int, underKey bool) (interface{}, error) {
+	if p.pos >= len(p.lines) {
+		return nil, nil
+	}
+	l := p.lines[p.pos]
+	if l.indent > indent || (underKey && l.indent == indent && isListItem(l.text)) {
+		return p.parseNode(l.indent)
+	}
+	return nil, nil
+}
+
+// Parses the value following "key:" or "- ", including any anchor, tag, block
+// scalar or continuation lines indented past the parent.
+func (p *yamlParser) parseValue(rest string, indent int, underKey bool) (interface{}, error) {
+	anchor := ""
+	if strings.HasPrefix(rest, "&") {
+		anchor, rest = splitToken(rest[1:])
+	}
+	if strings.HasPrefix(rest, "*") {
+		name, tail := splitToken(rest[1:])
+		if tail != "" {
+			return nil, p.errorf("unexpected text after alias *%v", name)
+		}
+		v, ok := p.anchors[name]
+		if !ok {
+			return nil, p.errorf("unknown alias *%v", name)
+		}
+		return v, nil
+	}
+	tag := ""
+	if strings.HasPrefix(rest, "!") {
+		tag, rest = splitToken(rest)
+	}
+	var v interface{}
+	var err error
+	switch {
+	case rest == "":
+		v, err = p.parseChild(indent, underKey)
+		if err == nil && tag != "" {
+			v, err = p.applyTag(tag, v)
+		}
+	case rest[0] == '|' || rest[0] == '>':
+		v, err = p.parseBlockScalar(rest[0] == '>', indent)
+		if err == nil && tag != "" {
+			v, err = p.applyTag(tag, v)
+		}
+	default:
+		for p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+			rest += " " + p.lines[p.pos].text
+			p.pos++
+		}
+		v, err = p.parseScalar(tag, rest)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if anchor != "" {
+		p.anchors[anchor] = v
+	}
+	return v, nil
+}
+
+// Reads the lines of a block scalar. Its indentation is set by the first line;
+// later lines may be indented further but not less.
+func (p *yamlParser) parseBlockScalar(folded bool, indent int) (string, error) {
+	var parts []string
+	base := -1
+	for p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+		l := p.lines[p.pos]
+		if base < 0 {
+			base = l.indent
+		}
+		if l.indent < base {
+			p.line = l.num
+			return "", p.errorf("block scalar line indented less than its first line")
+		}
+		parts = append(parts, strings.Repeat(" ", l.indent-base)+l.text)
+		p.pos++
+	}
+	if folded {
+		return strings.Join(parts, " "), nil
+	}
+	return strings.Join(parts, "\n"), nil
+}
+
+func (p *yamlParser) parseScalar(tag, s string) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(s, "'"):
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return nil, p.errorf("unterminated quoted string %v", s)
+		}
+		return p.applyQuotedTag(tag, strings.Replace(s[1:len(s)-1], "''", "'", -1))
+	case strings.HasPrefix(s, "\""):
+		u, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, p.errorf("invalid quoted string %v", s)
+		}
+		return p.applyQuotedTag(tag, u)
+	case strings.HasPrefix(s, "["):
+		if !strings.HasSuffix(s, "]") {
+			return nil, p.errorf("unterminated flow list %v", s)
+		}
+		ret := make([]interface{}, 0)
+		if inner := strings.TrimSpace(s[1 : len(s)-1]); inner != "" {
+			for _, item := range strings.Split(inner, ",") {
+				v, err := p.parseScalar("", strings.TrimSpace(item))
+				if err != nil {
+					return nil, err
+				}
+				ret = append(ret, v)
+			}
+		}
+		return ret, nil
+	case s == "{}":
+		return make(map[string]interface{}), nil
+	case strings.Contains(s, ": "):
+		return nil, p.errorf("mapping values are not allowed in plain scalar %q", s)
+	}
+	if tag != "" {
+		return p.applyTag(tag, s)
+	}
+	return resolveScalar(s), nil
+}
+
+// Quoted scalars are always strings unless explicitly tagged otherwise.
+func (p *yamlParser) applyQuotedTag(tag, s string) (interface{}, error) {
+	if tag == "" {
+		return s, nil
+	}
+	return p.applyTag(tag, s)
+}
+
+// Converts a value according to an explicit tag. Unknown tags are ignored.
+func (p *yamlParser) applyTag(tag string, v interface{}) (interface{}, error) {
+	s, isStr := v.(string)
+	switch tag {
+	case "!!str", "!!python/str", "!!python/unicode":
+		if !isStr {
+			return nil, p.errorf("%v applied to a non-scalar value", tag)
+		}
+		return s, nil
+	case "!!int", "!!python/int", "!!python/long":
+		if !isStr {
+			return nil, p.errorf("%v applied to a non-scalar value", tag)
+		}
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, p.errorf("invalid %v value %q", tag, s)
+		}
+		return i, nil
+	case "!!float", "!!python/float":
+		if !isStr {
+			return nil, p.errorf("%v applied to a non-scalar value", tag)
+		}
+		if f, ok := resolveScalar(s).(float64); ok {
+			return f, nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, p.errorf("invalid %v value %q", tag, s)
+		}
+		return f, nil
+	case "!!bool", "!!python/bool":
+		if b, ok := resolveScalar(s).(bool); ok && isStr {
+			return b, nil
+		}
+		return nil, p.errorf("invalid %v value %q", tag, s)
+	case "!!null", "!!python/none":
+		return nil, nil
+	}
+	if isStr {
+		return resolveScalar(s), nil
+	}
+	return v, nil
+}
+
+func resolveScalar(s string) interface{} {
+	switch s {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE", "yes", "Yes", "YES", "on", "On", "ON":
+		return true
+	case "false", "False", "FALSE", "no", "No", "NO", "off", "Off", "OFF":
+		return false
+	case ".inf", ".Inf", ".INF", "+.inf":
+		return math.Inf(1)
+	case "-.inf", "-.Inf", "-.INF":
+		return math.Inf(-1)
+	case ".nan", ".NaN", ".NAN":
+		return math.NaN()
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	if strings.ContainsAny(s, ".eE") {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	}
+	return s
+}
+
+// Splits "key: rest" or "key:", ignoring colons inside a quoted key.
+func splitKey(text string) (string, string, bool) {
+	start := 0
+	if text != "" && (text[0] == '\'' || text[0] == '"') {
+		end := strings.IndexByte(text[1:], text[0])
+		if end < 0 {
+			return "", "", false
+		}
+		start = end + 2
+	}
+	for i := start; i < len(text); i++ {
+		if text[i] != ':' || (i+1 < len(text) && text[i+1] != ' ') {
+			continue
+		}
+		k := strings.TrimSpace(text[:i])
+		if len(k) >= 2 && (k[0] == '\'' || k[0] == '"') {
+			k = k[1 : len(k)-1]
+		}
+		return k, strings.TrimSpace(text[i+1:]), true
+	}
+	return "", "", false
+}
+
+func splitToken(s string) (string, string) {
+	if i := strings.IndexByte(s, ' '); i >= 0 {
+		return s[:i], strings.TrimSpace(s[i+1:])
+	}
+	return s, ""
+}
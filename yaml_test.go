@@ -0,0 +1,111 @@
+package golink
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseYAMLScalars(t *testing.T) {
+	v, err := parseYAML(`
+int: 42
+negative: -7
+float: 0.52
+yes: true
+str: hello world
+quoted: '123'
+dquoted: "a: \"b\""
+empty:
+tagged: !!str 12
+long: !!python/long 1234567890123
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"int": int64(42), "negative": int64(-7), "float": 0.52, "yes": true,
+		"str": "hello world", "quoted": "123", "dquoted": `a: "b"`, "empty": nil,
+		"tagged": "12", "long": int64(1234567890123),
+	}
+	if !reflect.DeepEqual(v, expected) {
+		t.Errorf("Wrong values parsed. Got %#v", v)
+	}
+}
+
+func TestParseYAMLNested(t *testing.T) {
+	v, err := parseYAML(`
+wants:
+- quantity: 120
+  typeID: 4051
+- quantity: 5
+  typeID: 16275
+owner:
+  name: Some
+    Corp
+  ids: [1, 2]
+text: |
+  line one
+    line two
+alias: &id001 7
+other: *id001
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"wants": []interface{}{
+			map[string]interface{}{"quantity": int64(120), "typeID": int64(4051)},
+			map[string]interface{}{"quantity": int64(5), "typeID": int64(16275)},
+		},
+		"owner": map[string]interface{}{"name": "Some Corp", "ids": []interface{}{int64(1), int64(2)}},
+		"text":  "line one\n  line two",
+		"alias": int64(7),
+		"other": int64(7),
+	}
+	if !reflect.DeepEqual(v, expected) {
+		t.Errorf("Wrong values parsed. Got %#v", v)
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	for _, in := range []string{
+		"no separator here",
+		"a: 1\n    b: 2\n  c: 3",
+		"a: 'unterminated",
+		"a: !!int abc",
+		"a: *missing",
+		"a: 1\na: 2",
+		"text: |\n    foo\n  bar",
+		": |\n  0\n 0",
+	} {
+		_, err := parseYAML(in)
+		if _, ok := err.(*NotificationSyntaxError); !ok {
+			t.Errorf("Expected syntax error for %q, got %v", in, err)
+		}
+	}
+	_, err := parseYAML("a: 1\nb: 2\nbroken")
+	if e, ok := err.(*NotificationSyntaxError); !ok || e.Line != 3 {
+		t.Errorf("Expected syntax error on line 3, got %v", err)
+	}
+}
+
+func TestDecodeTowerResourceAlert(t *testing.T) {
+	body, err := DecodeNotification(76, "allianceID: 1\ncorpID: 2\nmoonID: 3\nsolarSystemID: 4\ntypeID: 16213\nwants:\n- quantity: 120\n  typeID: 4051\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(body, TowerResourceAlert{AllianceId: 1, CorpId: 2, MoonId: 3, SolarSystemId: 4, TypeId: 16213, Wants: []TowerResource{{TypeId: 4051, Quantity: 120}}}) {
+		t.Errorf("Wrong tower resource alert decoded. Got %+v", body)
+	}
+}
+
+func TestDecodeOrbitalReinforced(t *testing.T) {
+	body, err := DecodeNotification(94, "aggressorID: 3\nplanetID: 40000002\nreinforceExitTime: 129813516000000000\nsolarSystemID: 30000001\ntypeID: 2233\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, ok := body.(OrbitalReinforced)
+	if !ok || r.AggressorId != 3 || r.PlanetId != 40000002 || !r.ReinforceExitTime.Equal(time.Unix(1336878000, 0)) {
+		t.Errorf("Wrong orbital reinforcement decoded. Got %+v", body)
+	}
+}